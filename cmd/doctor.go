@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/PextraCloud/pce-mcp/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the configuration and the connection to PCE",
+	Long: `Check the configuration and the connection to PCE.
+
+Accepts the same flags and environment variables as serve, and reports the
+detected PCE version.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+
+		c, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("configuration: %w", err)
+		}
+		fmt.Fprintln(out, "Configuration: ok")
+		slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: c.SlogLevel()})))
+
+		client, err := api.New(c.PCEBaseURL, c.ClientOptions()...)
+		if err != nil {
+			return fmt.Errorf("PCE API client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), c.PCEDefaultTimeout)
+		defer cancel()
+		logout, err := authenticate(ctx, client, c)
+		if err != nil {
+			return fmt.Errorf("PCE login as %s: %w", c.PCEUsername, err)
+		}
+		defer logout()
+		info, apiErr := api.DetectVersion(ctx, client)
+		if apiErr != nil {
+			return fmt.Errorf("PCE API at %s: %w", c.PCEBaseURL, apiErr)
+		}
+		fmt.Fprintf(out, "PCE API: reachable at %s (node %s)\n", c.PCEBaseURL, info.NodeId)
+
+		if info.Version.IsZero() {
+			fmt.Fprintf(out, "PCE version: unknown (%q)\n", info.RawVersion)
+			return nil
+		}
+		fmt.Fprintf(out, "PCE version: %s\n", info.Version)
+		return nil
+	},
+}
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/PextraCloud/pce-mcp/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+var flagSchemaJSON bool
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+	schemaCmd.AddCommand(schemaCheckCmd)
+
+	schemaCheckCmd.Flags().BoolVar(&flagSchemaJSON, "json", false, "Print the report as JSON")
+}
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Inspect the PCE API schema",
+}
+
+var schemaCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check PCE API responses against the expected schema",
+	Long: `Check PCE API responses against the expected schema.
+
+Calls every read endpoint used by the tools, starting from the node serving the
+API, and reports for each the fields PCE sends that are not expected
+(unknown), the expected fields it doesn't send (missing) and the fields with a
+different JSON type (mismatched). Accepts the same flags and environment
+variables as serve. Exits with an error if any drift is found.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("configuration: %w", err)
+		}
+		slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: c.SlogLevel()})))
+
+		recorder := api.NewSchemaRecorder(nil)
+		client, err := api.New(c.PCEBaseURL, append(c.ClientOptions(), api.WithSchemaRecorder(recorder))...)
+		if err != nil {
+			return fmt.Errorf("PCE API client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), c.PCEDefaultTimeout)
+		defer cancel()
+		logout, err := authenticate(ctx, client, c)
+		if err != nil {
+			return fmt.Errorf("PCE login as %s: %w", c.PCEUsername, err)
+		}
+		defer logout()
+		info, apiErr := api.DetectVersion(ctx, client)
+		if apiErr != nil {
+			return fmt.Errorf("PCE API at %s: %w", c.PCEBaseURL, apiErr)
+		}
+		failures := checkReadEndpoints(cmd.Context(), client, info.NodeId, c.PCEDefaultTimeout)
+
+		report := recorder.Report()
+		if err := printSchemaReport(cmd.OutOrStdout(), report, failures); err != nil {
+			return err
+		}
+
+		drifted := 0
+		for _, d := range report {
+			if d.HasDrift() {
+				drifted++
+			}
+		}
+		switch {
+		case drifted > 0:
+			return fmt.Errorf("schema drift found in %d of %d endpoints", drifted, len(report))
+		case len(failures) > 0:
+			return fmt.Errorf("%d endpoints could not be checked", len(failures))
+		}
+		return nil
+	},
+}
+
+// schemaFailure is a read endpoint that could not be called.
+type schemaFailure struct {
+	Endpoint string `json:"endpoint"`
+	Error    string `json:"error"`
+}
+
+// checkReadEndpoints calls the read endpoints reachable from the node serving
+// the API (its cluster and organization); responses are checked by the client's
+// schema recorder.
+func checkReadEndpoints(ctx context.Context, client *api.Client, nodeId string, timeout time.Duration) []schemaFailure {
+	var failures []schemaFailure
+	call := func(endpoint string, fn func(ctx context.Context) *api.APIError) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		if apiErr := fn(ctx); apiErr != nil {
+			failures = append(failures, schemaFailure{Endpoint: endpoint, Error: apiErr.Error()})
+		}
+	}
+
+	var node *api.GetNodeByIdResponse
+	call("/v1/nodes/{id}", func(ctx context.Context) (apiErr *api.APIError) {
+		node, apiErr = api.GetNodeById(ctx, client, &api.GetNodeByIdArg{NodeId: nodeId})
+		return apiErr
+	})
+	if node == nil {
+		return failures
+	}
+	nodeId = node.Node.Id
+	clusterId := node.Node.ClusterId
+	organizationId := node.Node.OrganizationId
+
+	call("/v1/nodes/{id}/hardware", func(ctx context.Context) *api.APIError {
+		_, apiErr := api.GetNodeHardwareById(ctx, client, &api.GetNodeHardwareByIdArg{NodeId: nodeId})
+		return apiErr
+	})
+	call("/v1/nodes/{id}/license", func(ctx context.Context) *api.APIError {
+		_, apiErr := api.GetNodeLicenseById(ctx, client, &api.GetNodeLicenseByIdArg{NodeId: nodeId})
+		return apiErr
+	})
+	call("/v1/nodes/{id}/storage/pools", func(ctx context.Context) *api.APIError {
+		_, apiErr := api.GetNodeStoragePoolsById(ctx, client, &api.GetNodeStoragePoolsByIdArg{NodeId: nodeId})
+		return apiErr
+	})
+	call("/v1/nodes/{id}/hardware/pci", func(ctx context.Context) *api.APIError {
+		_, apiErr := api.GetNodePciDevicesById(ctx, client, &api.GetNodePciDevicesByIdArg{NodeId: nodeId})
+		return apiErr
+	})
+	call("/v1/nodes/{id}/images/images", func(ctx context.Context) *api.APIError {
+		_, apiErr := api.ListImagesByNode(ctx, client, &api.ListImagesByNodeArg{NodeId: nodeId})
+		return apiErr
+	})
+	var instances *api.GetInstancesByIdResponse
+	call("/v1/instances", func(ctx context.Context) (apiErr *api.APIError) {
+		instances, apiErr = api.GetInstancesById(ctx, client, &api.GetInstancesByIdArg{NodeId: nodeId})
+		return apiErr
+	})
+	if instances != nil && len(*instances) > 0 {
+		instanceId := (*instances)[0].Id
+		call("/v1/instances/{id}", func(ctx context.Context) *api.APIError {
+			_, apiErr := api.GetInstanceById(ctx, client, &api.GetInstanceByIdArg{NodeId: nodeId, InstanceId: instanceId})
+			return apiErr
+		})
+		call("/v1/instances/{id}/snapshots", func(ctx context.Context) *api.APIError {
+			_, apiErr := api.ListInstanceSnapshots(ctx, client, &api.ListInstanceSnapshotsArg{NodeId: nodeId, InstanceId: instanceId})
+			return apiErr
+		})
+	}
+
+	if clusterId != "" {
+		call("/v1/clusters/{id}/hardware", func(ctx context.Context) *api.APIError {
+			_, apiErr := api.GetClusterHardwareById(ctx, client, &api.GetClusterHardwareByIdArg{ClusterId: clusterId})
+			return apiErr
+		})
+		call("/v1/clusters/{id}/licensing", func(ctx context.Context) *api.APIError {
+			_, apiErr := api.GetClusterLicensingById(ctx, client, &api.GetClusterLicensingByIdArg{ClusterId: clusterId})
+			return apiErr
+		})
+	}
+
+	call("/v1/organizations", func(ctx context.Context) *api.APIError {
+		_, apiErr := api.ListOrganizations(ctx, client, &api.ListOrganizationsArg{})
+		return apiErr
+	})
+	if organizationId != "" {
+		call("/v1/organizations/{id}", func(ctx context.Context) *api.APIError {
+			_, apiErr := api.GetOrganizationById(ctx, client, &api.GetOrganizationByIdArg{OrganizationId: organizationId})
+			return apiErr
+		})
+		call("/v1/organizations/{id}/audit-logs", func(ctx context.Context) *api.APIError {
+			_, apiErr := api.ListOrganizationAuditLogsById(ctx, client, &api.ListOrganizationAuditLogsByIdArg{OrganizationId: organizationId})
+			return apiErr
+		})
+		call("/v1/users", func(ctx context.Context) *api.APIError {
+			_, apiErr := api.ListUsersInOrganizationById(ctx, client, &api.ListUsersInOrganizationByIdArg{OrganizationId: organizationId})
+			return apiErr
+		})
+	}
+
+	var tasks *api.ListTasksResponse
+	call("/v1/tasks", func(ctx context.Context) (apiErr *api.APIError) {
+		tasks, apiErr = api.ListTasks(ctx, client, &api.ListTasksArg{NodeId: nodeId, Limit: 1})
+		return apiErr
+	})
+	if tasks != nil && len(*tasks) > 0 {
+		taskId := (*tasks)[0].Id
+		call("/v1/tasks/{id}", func(ctx context.Context) *api.APIError {
+			_, apiErr := api.GetTask(ctx, client, &api.GetTaskArg{TaskId: taskId})
+			return apiErr
+		})
+	}
+	return failures
+}
+
+func printSchemaReport(w io.Writer, report []api.EndpointDrift, failures []schemaFailure) error {
+	if flagSchemaJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			Endpoints []api.EndpointDrift `json:"endpoints"`
+			Errors    []schemaFailure     `json:"errors,omitempty"`
+		}{report, failures})
+	}
+
+	for _, d := range report {
+		if !d.HasDrift() {
+			fmt.Fprintf(w, "%s: ok\n", d.Endpoint)
+			continue
+		}
+		fmt.Fprintf(w, "%s: drift (%s)\n", d.Endpoint, d.Type)
+		for _, field := range d.Unknown {
+			fmt.Fprintf(w, "  unknown: %s\n", field)
+		}
+		for _, field := range d.Missing {
+			fmt.Fprintf(w, "  missing: %s\n", field)
+		}
+		for _, field := range d.Mismatched {
+			fmt.Fprintf(w, "  mismatched: %s\n", field)
+		}
+	}
+	for _, f := range failures {
+		fmt.Fprintf(w, "%s: error: %s\n", f.Endpoint, strings.TrimSpace(f.Error))
+	}
+	return nil
+}
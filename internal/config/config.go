@@ -17,11 +17,16 @@ package config
 
 import (
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/PextraCloud/pce-mcp/pkg/api"
 )
 
 const (
@@ -32,6 +37,36 @@ const (
 	EnvTLSSkipVerify = "TLS_SKIP_VERIFY"
 	EnvTimeout       = "TIMEOUT"
 	EnvCACert        = "TLS_CA_CERT"
+	EnvClientCert    = "TLS_CLIENT_CERT"
+	EnvClientKey     = "TLS_CLIENT_KEY"
+	EnvProxyURL      = "PROXY_URL"
+	EnvUnixSocket    = "UNIX_SOCKET"
+	EnvUsername      = "PCE_USERNAME"
+	EnvPasswordFile  = "PCE_PASSWORD_FILE"
+
+	EnvReadTimeout     = "READ_TIMEOUT"
+	EnvMutationTimeout = "MUTATION_TIMEOUT"
+	EnvToolTimeouts    = "TOOL_TIMEOUTS"
+
+	EnvRetryMaxAttempts = "RETRY_MAX_ATTEMPTS"
+	EnvRetryBackoff     = "RETRY_BACKOFF"
+	EnvRetryMaxBackoff  = "RETRY_MAX_BACKOFF"
+
+	EnvCacheTTL   = "CACHE_TTL"
+	EnvCacheRules = "CACHE_RULES"
+
+	EnvRateLimitGlobal   = "RATE_LIMIT_GLOBAL"
+	EnvRateLimitSession  = "RATE_LIMIT_SESSION"
+	EnvRateLimitRead     = "RATE_LIMIT_READ"
+	EnvRateLimitMutation = "RATE_LIMIT_MUTATION"
+
+	EnvBreakerThreshold     = "BREAKER_THRESHOLD"
+	EnvBreakerProbeInterval = "BREAKER_PROBE_INTERVAL"
+
+	EnvMaxResponseSize = "MAX_RESPONSE_SIZE"
+	EnvStrictDecode    = "STRICT_DECODE"
+
+	EnvLogLevel = "LOG_LEVEL"
 )
 
 // AppConfig holds runtime configuration for the server and API client.
@@ -41,12 +76,53 @@ type AppConfig struct {
 	HTTPAddr     string
 	DisableStdio bool
 
+	// Logging (debug, info, warn or error); debug logs PCE API traffic
+	LogLevel string
+
 	// PCE API client
 	PCEBaseURL        string
 	PCEInsecureTLS    bool
 	PCECACertPath     string
+	PCEClientCertPath string
+	PCEClientKeyPath  string
 	PCEDefaultTimeout time.Duration
 	PCECustomHeaders  http.Header
+	PCEProxyURL       string
+	PCEUnixSocket     string
+
+	// Timeouts per endpoint class (zero uses PCEDefaultTimeout) and per tool
+	PCEReadTimeout     time.Duration
+	PCEMutationTimeout time.Duration
+	PCEToolTimeouts    []string // "<tool name>=<timeout>"
+
+	// PCE credentials, for sessions without their own (e.g., stdio)
+	PCEUsername     string
+	PCEPasswordFile string
+
+	// PCE API client retries (zero values select the defaults)
+	PCERetryMaxAttempts    int
+	PCERetryInitialBackoff time.Duration
+	PCERetryMaxBackoff     time.Duration
+
+	// Per-session GET response cache (disabled unless a TTL or rule is set)
+	PCECacheTTL   time.Duration
+	PCECacheRules []string // "<path pattern>=<ttl>", checked before the defaults
+
+	// Client-side rate limits in requests per second (0 disables)
+	PCERateLimitGlobal   float64
+	PCERateLimitSession  float64
+	PCERateLimitRead     float64
+	PCERateLimitMutation float64
+
+	// Circuit breaker (zero values select the defaults, negative threshold disables)
+	PCEBreakerThreshold     int
+	PCEBreakerProbeInterval time.Duration
+
+	// Maximum PCE API response size in bytes (zero selects the default, negative disables)
+	PCEMaxResponseSize int64
+
+	// Log differences between PCE API responses and the expected schema
+	PCEStrictDecode bool
 }
 
 var cfg AppConfig
@@ -81,6 +157,32 @@ func WithEnvDefaults(c AppConfig) (*AppConfig, error) {
 			c.PCECACertPath = v
 		}
 	}
+	if c.PCEUsername == "" {
+		c.PCEUsername = os.Getenv(EnvUsername)
+	}
+	if c.PCEPasswordFile == "" {
+		c.PCEPasswordFile = os.Getenv(EnvPasswordFile)
+	}
+	if c.PCEClientCertPath == "" {
+		if v := os.Getenv(EnvClientCert); v != "" {
+			c.PCEClientCertPath = v
+		}
+	}
+	if c.PCEClientKeyPath == "" {
+		if v := os.Getenv(EnvClientKey); v != "" {
+			c.PCEClientKeyPath = v
+		}
+	}
+	if c.PCEProxyURL == "" {
+		if v := os.Getenv(EnvProxyURL); v != "" {
+			c.PCEProxyURL = v
+		}
+	}
+	if c.PCEUnixSocket == "" {
+		if v := os.Getenv(EnvUnixSocket); v != "" {
+			c.PCEUnixSocket = v
+		}
+	}
 
 	// Booleans: apply env if provided (validate on parse failure)
 	if v := os.Getenv(EnvTLSSkipVerify); v != "" {
@@ -90,6 +192,13 @@ func WithEnvDefaults(c AppConfig) (*AppConfig, error) {
 			return nil, validationError{msgs: []string{fmt.Sprintf("invalid %s: %s", EnvTLSSkipVerify, v)}}
 		}
 	}
+	if v := os.Getenv(EnvStrictDecode); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			c.PCEStrictDecode = b
+		} else {
+			return nil, validationError{msgs: []string{fmt.Sprintf("invalid %s: %s", EnvStrictDecode, v)}}
+		}
+	}
 	if v := os.Getenv(EnvDisableStdio); v != "" {
 		if b, err := strconv.ParseBool(v); err == nil {
 			c.DisableStdio = b
@@ -109,6 +218,147 @@ func WithEnvDefaults(c AppConfig) (*AppConfig, error) {
 		}
 	}
 
+	for _, t := range []struct {
+		env string
+		v   *time.Duration
+	}{
+		{EnvReadTimeout, &c.PCEReadTimeout},
+		{EnvMutationTimeout, &c.PCEMutationTimeout},
+	} {
+		if *t.v != 0 {
+			continue
+		}
+		if v := os.Getenv(t.env); v != "" {
+			if d, err := time.ParseDuration(v); err == nil && d > 0 {
+				*t.v = d
+			} else {
+				return nil, validationError{msgs: []string{fmt.Sprintf("invalid %s: %s", t.env, v)}}
+			}
+		}
+	}
+	if len(c.PCEToolTimeouts) == 0 {
+		if v := os.Getenv(EnvToolTimeouts); v != "" {
+			c.PCEToolTimeouts = strings.Split(v, ",")
+		}
+	}
+
+	// Retry policy: env override if provided, then defaults
+	if c.PCERetryMaxAttempts == 0 {
+		if v := os.Getenv(EnvRetryMaxAttempts); v != "" {
+			if n, err := strconv.Atoi(v); err == nil && n > 0 {
+				c.PCERetryMaxAttempts = n
+			} else {
+				return nil, validationError{msgs: []string{fmt.Sprintf("invalid %s: %s", EnvRetryMaxAttempts, v)}}
+			}
+		} else {
+			c.PCERetryMaxAttempts = api.DefaultRetryMaxAttempts
+		}
+	}
+	if c.PCERetryInitialBackoff == 0 {
+		if v := os.Getenv(EnvRetryBackoff); v != "" {
+			if d, err := time.ParseDuration(v); err == nil && d > 0 {
+				c.PCERetryInitialBackoff = d
+			} else {
+				return nil, validationError{msgs: []string{fmt.Sprintf("invalid %s: %s", EnvRetryBackoff, v)}}
+			}
+		} else {
+			c.PCERetryInitialBackoff = api.DefaultRetryInitialBackoff
+		}
+	}
+	if c.PCERetryMaxBackoff == 0 {
+		if v := os.Getenv(EnvRetryMaxBackoff); v != "" {
+			if d, err := time.ParseDuration(v); err == nil && d > 0 {
+				c.PCERetryMaxBackoff = d
+			} else {
+				return nil, validationError{msgs: []string{fmt.Sprintf("invalid %s: %s", EnvRetryMaxBackoff, v)}}
+			}
+		} else {
+			c.PCERetryMaxBackoff = api.DefaultRetryMaxBackoff
+		}
+	}
+
+	// Cache: env override if provided
+	if c.PCECacheTTL == 0 {
+		if v := os.Getenv(EnvCacheTTL); v != "" {
+			if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+				c.PCECacheTTL = d
+			} else {
+				return nil, validationError{msgs: []string{fmt.Sprintf("invalid %s: %s", EnvCacheTTL, v)}}
+			}
+		}
+	}
+	if len(c.PCECacheRules) == 0 {
+		if v := os.Getenv(EnvCacheRules); v != "" {
+			c.PCECacheRules = strings.Split(v, ",")
+		}
+	}
+
+	// Rate limits: env override if provided
+	for _, rl := range []struct {
+		env string
+		v   *float64
+	}{
+		{EnvRateLimitGlobal, &c.PCERateLimitGlobal},
+		{EnvRateLimitSession, &c.PCERateLimitSession},
+		{EnvRateLimitRead, &c.PCERateLimitRead},
+		{EnvRateLimitMutation, &c.PCERateLimitMutation},
+	} {
+		if *rl.v != 0 {
+			continue
+		}
+		if v := os.Getenv(rl.env); v != "" {
+			if f, err := strconv.ParseFloat(v, 64); err == nil && f >= 0 {
+				*rl.v = f
+			} else {
+				return nil, validationError{msgs: []string{fmt.Sprintf("invalid %s: %s", rl.env, v)}}
+			}
+		}
+	}
+
+	// Circuit breaker: env override if provided, then defaults
+	if c.PCEBreakerThreshold == 0 {
+		if v := os.Getenv(EnvBreakerThreshold); v != "" {
+			if n, err := strconv.Atoi(v); err == nil && n != 0 {
+				c.PCEBreakerThreshold = n
+			} else {
+				return nil, validationError{msgs: []string{fmt.Sprintf("invalid %s: %s", EnvBreakerThreshold, v)}}
+			}
+		} else {
+			c.PCEBreakerThreshold = api.DefaultBreakerThreshold
+		}
+	}
+	if c.PCEBreakerProbeInterval == 0 {
+		if v := os.Getenv(EnvBreakerProbeInterval); v != "" {
+			if d, err := time.ParseDuration(v); err == nil && d > 0 {
+				c.PCEBreakerProbeInterval = d
+			} else {
+				return nil, validationError{msgs: []string{fmt.Sprintf("invalid %s: %s", EnvBreakerProbeInterval, v)}}
+			}
+		} else {
+			c.PCEBreakerProbeInterval = api.DefaultBreakerProbeInterval
+		}
+	}
+
+	// Response size limit: env override if provided, then default
+	if c.PCEMaxResponseSize == 0 {
+		if v := os.Getenv(EnvMaxResponseSize); v != "" {
+			if n, err := strconv.ParseInt(v, 10, 64); err == nil && n != 0 {
+				c.PCEMaxResponseSize = n
+			} else {
+				return nil, validationError{msgs: []string{fmt.Sprintf("invalid %s: %s", EnvMaxResponseSize, v)}}
+			}
+		} else {
+			c.PCEMaxResponseSize = api.DefaultMaxResponseSize
+		}
+	}
+
+	if c.LogLevel == "" {
+		c.LogLevel = os.Getenv(EnvLogLevel)
+		if c.LogLevel == "" {
+			c.LogLevel = "info"
+		}
+	}
+
 	// collect validation issues
 	errs := []string{}
 
@@ -134,15 +384,96 @@ func WithEnvDefaults(c AppConfig) (*AppConfig, error) {
 		}
 	}
 
+	// Client certificate and key must be provided together
+	if (c.PCEClientCertPath == "") != (c.PCEClientKeyPath == "") {
+		errs = append(errs, fmt.Sprintf("%s and %s must be set together", EnvClientCert, EnvClientKey))
+	}
+	if c.PCEClientCertPath != "" {
+		if _, err := os.Stat(c.PCEClientCertPath); err != nil {
+			errs = append(errs, fmt.Sprintf("%s points to invalid path: %v", EnvClientCert, err))
+		}
+	}
+	if c.PCEClientKeyPath != "" {
+		if _, err := os.Stat(c.PCEClientKeyPath); err != nil {
+			errs = append(errs, fmt.Sprintf("%s points to invalid path: %v", EnvClientKey, err))
+		}
+	}
+
+	// Username and password file must be provided together
+	if (c.PCEUsername == "") != (c.PCEPasswordFile == "") {
+		errs = append(errs, fmt.Sprintf("%s and %s must be set together", EnvUsername, EnvPasswordFile))
+	}
+	if c.PCEPasswordFile != "" {
+		if _, err := c.Password(); err != nil {
+			errs = append(errs, fmt.Sprintf("%s is invalid: %v", EnvPasswordFile, err))
+		}
+	}
+
 	// TLS flags mutual exclusivity: don't allow both skip-verify and custom CA
 	if c.PCEInsecureTLS && c.PCECACertPath != "" {
 		errs = append(errs, fmt.Sprintf("only one of %s or %s may be set", EnvTLSSkipVerify, EnvCACert))
 	}
 
+	// Proxy URL must be absolute; never echo it back since it may hold credentials
+	if c.PCEProxyURL != "" {
+		if u, err := url.Parse(c.PCEProxyURL); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Sprintf("%s is invalid: expected scheme://[user:password@]host:port", EnvProxyURL))
+		}
+	}
+
+	// Unix socket must exist and is incompatible with a proxy
+	if c.PCEUnixSocket != "" {
+		if _, err := os.Stat(c.PCEUnixSocket); err != nil {
+			errs = append(errs, fmt.Sprintf("%s points to invalid path: %v", EnvUnixSocket, err))
+		}
+		if c.PCEProxyURL != "" {
+			errs = append(errs, fmt.Sprintf("only one of %s or %s may be set", EnvProxyURL, EnvUnixSocket))
+		}
+	}
+
 	// Timeout must be positive
 	if c.PCEDefaultTimeout <= 0 {
 		errs = append(errs, fmt.Sprintf("%s must be > 0 (seconds)", EnvTimeout))
 	}
+	if c.PCEReadTimeout < 0 || c.PCEMutationTimeout < 0 {
+		errs = append(errs, fmt.Sprintf("%s and %s must be > 0", EnvReadTimeout, EnvMutationTimeout))
+	}
+	if _, err := parseToolTimeouts(c.PCEToolTimeouts); err != nil {
+		errs = append(errs, fmt.Sprintf("invalid %s: %v", EnvToolTimeouts, err))
+	}
+
+	// Retry policy sanity
+	if c.PCERetryMaxAttempts < 1 {
+		errs = append(errs, fmt.Sprintf("%s must be >= 1", EnvRetryMaxAttempts))
+	}
+	if c.PCERetryInitialBackoff < 0 || c.PCERetryMaxBackoff < 0 {
+		errs = append(errs, fmt.Sprintf("%s and %s must be > 0", EnvRetryBackoff, EnvRetryMaxBackoff))
+	} else if c.PCERetryInitialBackoff > c.PCERetryMaxBackoff {
+		errs = append(errs, fmt.Sprintf("%s must not exceed %s", EnvRetryBackoff, EnvRetryMaxBackoff))
+	}
+
+	// Cache rules must parse
+	if c.PCECacheTTL < 0 {
+		errs = append(errs, fmt.Sprintf("%s must be >= 0", EnvCacheTTL))
+	}
+	if _, err := parseCacheRules(c.PCECacheRules); err != nil {
+		errs = append(errs, fmt.Sprintf("invalid %s: %v", EnvCacheRules, err))
+	}
+
+	// Rate limits must not be negative
+	if c.PCERateLimitGlobal < 0 || c.PCERateLimitSession < 0 || c.PCERateLimitRead < 0 || c.PCERateLimitMutation < 0 {
+		errs = append(errs, "rate limits must be >= 0 (requests per second)")
+	}
+
+	if c.PCEBreakerProbeInterval < 0 {
+		errs = append(errs, fmt.Sprintf("%s must be > 0", EnvBreakerProbeInterval))
+	}
+
+	// Log level must be known to slog
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		errs = append(errs, fmt.Sprintf("invalid %s: %s (expected debug, info, warn or error)", EnvLogLevel, c.LogLevel))
+	}
 
 	if len(errs) > 0 {
 		return nil, validationError{msgs: errs}
@@ -150,6 +481,117 @@ func WithEnvDefaults(c AppConfig) (*AppConfig, error) {
 	return &c, nil
 }
 
+// Password reads the PCE password from the password file. Trailing newlines
+// are ignored. The file is read on every call so that it can be rotated.
+func (c AppConfig) Password() (string, error) {
+	data, err := os.ReadFile(c.PCEPasswordFile)
+	if err != nil {
+		return "", err
+	}
+	password := strings.TrimRight(string(data), "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("password file is empty")
+	}
+	return password, nil
+}
+
+// SlogLevel returns the configured log level, defaulting to info.
+func (c AppConfig) SlogLevel() slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
+// ClientOptions returns the PCE API client options described by c.
+func (c AppConfig) ClientOptions() []api.Option {
+	return []api.Option{
+		api.WithInsecureSkipVerify(c.PCEInsecureTLS),
+		api.WithCACertFile(c.PCECACertPath),
+		api.WithClientCertificate(c.PCEClientCertPath, c.PCEClientKeyPath),
+		api.WithTimeouts(api.Timeouts{
+			Default:  c.PCEDefaultTimeout,
+			Read:     c.PCEReadTimeout,
+			Mutation: c.PCEMutationTimeout,
+		}),
+		api.WithHeaders(c.PCECustomHeaders),
+		api.WithProxy(c.PCEProxyURL),
+		api.WithUnixSocket(c.PCEUnixSocket),
+		api.WithRetryPolicy(api.RetryPolicy{
+			MaxAttempts:    c.PCERetryMaxAttempts,
+			InitialBackoff: c.PCERetryInitialBackoff,
+			MaxBackoff:     c.PCERetryMaxBackoff,
+		}),
+		api.WithCache(c.newCache()),
+		api.WithMaxResponseSize(max(0, c.PCEMaxResponseSize)),
+		api.WithMiddleware(api.DebugLogging(slog.Default())),
+	}
+}
+
+// newCache returns a fresh response cache, or nil if caching is disabled.
+func (c AppConfig) newCache() *api.Cache {
+	rules, _ := parseCacheRules(c.PCECacheRules) // validated in WithEnvDefaults
+	if c.PCECacheTTL > 0 {
+		rules = append(rules, api.DefaultCacheRules(c.PCECacheTTL)...)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	return api.NewCache(rules...)
+}
+
+// parseCacheRules parses "<path pattern>=<ttl>" specs, e.g. "/v1/nodes/*=30s".
+func parseCacheRules(specs []string) ([]api.CacheRule, error) {
+	rules := make([]api.CacheRule, 0, len(specs))
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		pattern, ttl, ok := strings.Cut(spec, "=")
+		if !ok || !strings.HasPrefix(pattern, "/") {
+			return nil, fmt.Errorf("%q: expected <path pattern>=<ttl>", spec)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%q: %v", spec, err)
+		}
+		d, err := time.ParseDuration(ttl)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("%q: invalid ttl", spec)
+		}
+		rules = append(rules, api.CacheRule{Pattern: pattern, TTL: d})
+	}
+	return rules, nil
+}
+
+// ToolTimeouts returns the configured timeout of each tool, by tool name.
+func (c AppConfig) ToolTimeouts() map[string]time.Duration {
+	timeouts, _ := parseToolTimeouts(c.PCEToolTimeouts) // validated in WithEnvDefaults
+	return timeouts
+}
+
+// parseToolTimeouts parses "<tool name>=<timeout>" specs, e.g. "wait_for_task=5m".
+func parseToolTimeouts(specs []string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration, len(specs))
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		name, timeout, ok := strings.Cut(spec, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%q: expected <tool name>=<timeout>", spec)
+		}
+		d, err := time.ParseDuration(timeout)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("%q: invalid timeout", spec)
+		}
+		timeouts[name] = d
+	}
+	return timeouts, nil
+}
+
 // validationError collects validation messages.
 type validationError struct {
 	msgs []string
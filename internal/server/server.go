@@ -20,7 +20,9 @@ import (
 	"log"
 	"os"
 
+	"github.com/PextraCloud/pce-mcp/internal/config"
 	"github.com/PextraCloud/pce-mcp/internal/session"
+	"github.com/PextraCloud/pce-mcp/pkg/pce"
 	"github.com/mark3labs/mcp-go/server"
 )
 
@@ -43,6 +45,8 @@ func GetServer() *server.MCPServer {
 		server.WithPromptCapabilities(true),
 		server.WithLogging(),
 		server.WithRecovery(),
+		server.WithToolHandlerMiddleware(pce.RequestID),
+		server.WithToolHandlerMiddleware(pce.Timeouts(config.Get().ToolTimeouts())),
 	)
 
 	return s
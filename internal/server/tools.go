@@ -16,15 +16,26 @@ limitations under the License.
 package server
 
 import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/PextraCloud/pce-mcp/internal/session"
+	"github.com/PextraCloud/pce-mcp/pkg/api"
 	"github.com/PextraCloud/pce-mcp/pkg/pce"
 	"github.com/mark3labs/mcp-go/server"
 )
 
+func addAuthTools(s *server.MCPServer) {
+	s.AddTool(pce.Login())
+	s.AddTool(pce.Logout())
+}
+
 func addOrganizationTools(s *server.MCPServer) {
 	s.AddTool(pce.ListOrganizations())
 	s.AddTool(pce.GetOrganizationById())
 	s.AddTool(pce.GetCurrentOrganization())
-	// s.AddTool(pce.ListOrganizationAuditLogsById())
+	s.AddTool(pce.ListOrganizationAuditLogsById())
 	// s.AddTool(pce.ListOrganizationUserLockoutsById())
 	s.AddTool(pce.CreateOrganization())
 	s.AddTool(pce.DeleteOrganizationById())
@@ -54,13 +65,64 @@ func addNodeTools(s *server.MCPServer) {
 func addInstanceTools(s *server.MCPServer) {
 	s.AddTool(pce.GetInstancesInNode())
 	s.AddTool(pce.GetInstancesInCluster())
+	s.AddTool(pce.GetInstanceById())
+	s.AddTool(pce.CreateInstance())
+	s.AddTool(pce.DeleteInstance())
 	s.AddTool(pce.PowerInstance())
+	s.AddTool(pce.MigrateInstance())
+}
+
+func addSnapshotTools(s *server.MCPServer) {
+	s.AddTool(pce.ListInstanceSnapshots())
+	s.AddTool(pce.CreateInstanceSnapshot())
+	s.AddTool(pce.RevertInstanceSnapshot())
+	s.AddTool(pce.DeleteInstanceSnapshot())
+}
+
+func addTaskTools(s *server.MCPServer) {
+	s.AddTool(pce.GetTaskStatus())
+	s.AddTool(pce.ListRecentTasks())
+	s.AddTool(pce.WaitForTask())
+}
+
+func addStatusTools(s *server.MCPServer) {
+	s.AddTool(pce.ServerStatus())
+}
+
+// LogVersionOnLogin installs a login hook logging the version of the
+// connected PCE, detected with the client of the first successful login,
+// whether through the login tool or the configured credentials.
+func LogVersionOnLogin() {
+	var mu sync.Mutex
+	detected := false
+	session.LoginHook = func(ctx context.Context, client *api.Client) {
+		mu.Lock()
+		defer mu.Unlock()
+		if detected {
+			return
+		}
+		info, apiErr := api.DetectVersion(ctx, client)
+		if apiErr != nil {
+			log.Printf("PCE version detection failed: %v", apiErr)
+			return
+		}
+		detected = true
+		if info.Version.IsZero() {
+			log.Printf("Connected to PCE of unknown version %q (node %s)", info.RawVersion, info.NodeId)
+		} else {
+			log.Printf("Connected to PCE %s (node %s)", info.Version, info.NodeId)
+		}
+	}
 }
 
 func AddTools(s *server.MCPServer) {
+	addAuthTools(s)
 	addOrganizationTools(s)
 	addUserTools(s)
 	addClusterTools(s)
 	addNodeTools(s)
 	addInstanceTools(s)
+	addSnapshotTools(s)
+	addTaskTools(s)
+	addStatusTools(s)
 }
@@ -16,7 +16,10 @@ limitations under the License.
 package session
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"sync"
 	"time"
 
@@ -25,10 +28,26 @@ import (
 )
 
 type sessionEntry struct {
+	// Never modified; calls get clones with their credentials
 	client *api.Client
 	mu     sync.Mutex
+
+	// PCE session token bound by Login
+	token    string
+	username string
+	expires  time.Time
+	// Whether the token was obtained with the configured credentials
+	fromConfig bool
 }
 
+// LoginHook, if set, is called in the background after every successful
+// login with a client carrying the new token, e.g. to detect the PCE version
+// once credentials exist.
+var LoginHook func(ctx context.Context, client *api.Client)
+
+// Session tokens are renewed when they expire within refreshBefore
+const refreshBefore = api.TokenRefreshBefore
+
 // <session id> -> *api.Client
 var (
 	sessionStore = make(map[string]*sessionEntry)
@@ -37,11 +56,12 @@ var (
 
 func getApiClient() (*api.Client, error) {
 	c := config.Get()
-	timeout := c.PCEDefaultTimeout
-	if timeout <= 0 {
-		timeout = 10 * time.Second
-	}
-	client, err := api.NewClient(c.PCEBaseURL, c.PCEInsecureTLS, timeout, c.PCECACertPath, c.PCECustomHeaders)
+	opts := append(c.ClientOptions(),
+		api.WithRateLimits(rateLimits(c)),
+		api.WithCircuitBreaker(circuitBreaker(c)),
+		api.WithSchemaRecorder(schemaRecorder(c)),
+	)
+	client, err := api.New(c.PCEBaseURL, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create MCP client: %w", err)
 	}
@@ -49,6 +69,57 @@ func getApiClient() (*api.Client, error) {
 	return client, nil
 }
 
+// State shared by all sessions, since they talk to the same PCE
+var (
+	sharedOnce    sync.Once
+	sharedLimits  api.RateLimits
+	sharedBreaker *api.CircuitBreaker
+	sharedSchema  *api.SchemaRecorder
+)
+
+func initShared(c config.AppConfig) {
+	sharedOnce.Do(func() {
+		sharedLimits = api.RateLimits{
+			Global:   api.NewRateLimiter(c.PCERateLimitGlobal, 0),
+			Read:     api.NewRateLimiter(c.PCERateLimitRead, 0),
+			Mutation: api.NewRateLimiter(c.PCERateLimitMutation, 0),
+		}
+		sharedBreaker = api.NewCircuitBreaker(c.PCEBreakerThreshold, c.PCEBreakerProbeInterval)
+		if c.PCEStrictDecode {
+			sharedSchema = api.NewSchemaRecorder(slog.Default())
+		}
+	})
+}
+
+// Close stops background work shared by all sessions, such as circuit breaker
+// probes.
+func Close() {
+	// Synchronizes with initShared; no-op if no session was ever created
+	sharedOnce.Do(func() {})
+	sharedBreaker.Close()
+}
+
+// rateLimits returns the shared limiters plus a new per-session limiter.
+func rateLimits(c config.AppConfig) api.RateLimits {
+	initShared(c)
+	limits := sharedLimits
+	limits.Client = api.NewRateLimiter(c.PCERateLimitSession, 0)
+	return limits
+}
+
+// circuitBreaker returns the breaker shared by all sessions.
+func circuitBreaker(c config.AppConfig) *api.CircuitBreaker {
+	initShared(c)
+	return sharedBreaker
+}
+
+// schemaRecorder returns the schema drift recorder shared by all sessions, or
+// nil unless strict decoding is enabled.
+func schemaRecorder(c config.AppConfig) *api.SchemaRecorder {
+	initShared(c)
+	return sharedSchema
+}
+
 func RegisterSession(id string) error {
 	if id == "" {
 		return fmt.Errorf("session id is required")
@@ -69,29 +140,113 @@ func UnregisterSession(id string) {
 	sessionMu.Unlock()
 }
 
-func GetSession(id string, authorization string) (*api.Client, error) {
-	if id == "" {
-		return nil, fmt.Errorf("session id is required")
+// GetSession returns the client of session id. Requests are authenticated
+// with, in order of precedence: the token bound by Login, authorization (from
+// the MCP HTTP request), the configured username and password, and the
+// Authorization header configured with --headers.
+func GetSession(ctx context.Context, id string, authorization string) (*api.Client, error) {
+	entry, err := getEntry(id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.token != "" && !entry.expires.IsZero() && time.Until(entry.expires) < refreshBefore {
+		if err := entry.refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if entry.token == "" && authorization == "" && config.Get().PCEUsername != "" {
+		if err := entry.loginWithConfig(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	client := entry.clientWith(authorization)
+	if entry.token != "" {
+		// client is a clone carrying the token
+		client.Reauthenticate = entry.reauthenticate(entry.token)
+		client.TokenExpiry = entry.expires
 	}
+	return client, nil
+}
 
+// Login logs in to PCE and binds the session token to session id. It returns
+// the token expiry, or the zero time if unknown.
+func Login(ctx context.Context, id, username, password, totp string) (time.Time, error) {
+	entry, err := getEntry(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if err := entry.login(ctx, username, password, totp); err != nil {
+		return time.Time{}, err
+	}
+	return entry.expires, nil
+}
+
+// Logout invalidates the session token bound to session id and unbinds it.
+func Logout(ctx context.Context, id string) error {
+	entry, err := getEntry(id)
+	if err != nil {
+		return err
+	}
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.token == "" {
+		return fmt.Errorf("not logged in")
+	}
+	apiErr := api.Logout(ctx, entry.clientWith(""))
+
+	// An expired token is as good as invalidated
+	entry.unbind()
+	if apiErr != nil && !apiErr.IsUnauthorized() {
+		return apiErr
+	}
+	return nil
+}
+
+// Username returns the user logged in to session id with Login, if any.
+func Username(id string) string {
 	sessionMu.RLock()
 	entry, ok := sessionStore[id]
 	sessionMu.RUnlock()
 	if !ok {
-		var err error
-		entry, err = newSessionEntry()
-		if err != nil {
-			return nil, err
-		}
-		sessionMu.Lock()
-		sessionStore[id] = entry
-		sessionMu.Unlock()
+		return ""
 	}
-
 	entry.mu.Lock()
 	defer entry.mu.Unlock()
-	setAuthorization(entry.client, authorization)
-	return entry.client, nil
+	return entry.username
+}
+
+func getEntry(id string) (*sessionEntry, error) {
+	if id == "" {
+		return nil, fmt.Errorf("session id is required")
+	}
+
+	sessionMu.RLock()
+	entry, ok := sessionStore[id]
+	sessionMu.RUnlock()
+	if ok {
+		return entry, nil
+	}
+
+	entry, err := newSessionEntry()
+	if err != nil {
+		return nil, err
+	}
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+	// Another call may have raced us
+	if existing, ok := sessionStore[id]; ok {
+		return existing, nil
+	}
+	sessionStore[id] = entry
+	return entry, nil
 }
 
 func newSessionEntry() (*sessionEntry, error) {
@@ -102,13 +257,105 @@ func newSessionEntry() (*sessionEntry, error) {
 	return &sessionEntry{client: client}, nil
 }
 
-func setAuthorization(client *api.Client, authorization string) {
-	if client == nil {
-		return
+// login logs in and binds the token. The caller holds e.mu.
+func (e *sessionEntry) login(ctx context.Context, username, password, totp string) error {
+	resp, apiErr := api.Login(ctx, e.client, username, password, totp)
+	if apiErr != nil {
+		return apiErr
+	}
+	e.token = resp.Token
+	e.username = username
+	e.expires = resp.Expiry()
+	e.fromConfig = false
+
+	if LoginHook != nil {
+		client := e.clientWith("")
+		go func() {
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), config.Get().PCEDefaultTimeout)
+			defer cancel()
+			LoginHook(ctx, client)
+		}()
+	}
+	return nil
+}
+
+// unbind forgets the token. The caller holds e.mu.
+func (e *sessionEntry) unbind() {
+	e.token, e.username, e.expires, e.fromConfig = "", "", time.Time{}, false
+}
+
+// loginWithConfig logs in with the configured credentials. The caller holds e.mu.
+func (e *sessionEntry) loginWithConfig(ctx context.Context) error {
+	c := config.Get()
+	password, err := c.Password()
+	if err != nil {
+		return fmt.Errorf("failed to read PCE password file: %w", err)
+	}
+	if err := e.login(ctx, c.PCEUsername, password, ""); err != nil {
+		if errors.Is(err, api.ErrMFARequired) {
+			return fmt.Errorf("PCE user %s requires multi-factor authentication; call the login tool with a TOTP code: %w", c.PCEUsername, err)
+		}
+		return err
+	}
+	e.fromConfig = true
+	return nil
+}
+
+// refresh renews the token. A token obtained with the configured credentials
+// is replaced by logging in again if it can't be renewed; any other is unbound
+// and the session expires. The caller holds e.mu.
+func (e *sessionEntry) refresh(ctx context.Context) error {
+	if e.token != "" {
+		resp, apiErr := api.RefreshToken(ctx, e.clientWith(""))
+		if apiErr == nil {
+			e.token, e.expires = resp.Token, resp.Expiry()
+			return nil
+		}
+		slog.DebugContext(ctx, "PCE session token refresh failed", "username", e.username, "error", apiErr)
+		fromConfig := e.fromConfig
+		e.unbind()
+		if !fromConfig {
+			return api.SessionExpiredError(apiErr)
+		}
+	}
+	if config.Get().PCEUsername == "" {
+		return api.SessionExpiredError(nil)
+	}
+	if err := e.loginWithConfig(ctx); err != nil {
+		return api.SessionExpiredError(err)
+	}
+	return nil
+}
+
+// reauthenticate returns the Reauthenticate callback of a client using token.
+func (e *sessionEntry) reauthenticate(token string) func(context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		// The session was logged out (or expired) since the request started
+		if e.token == "" {
+			return "", api.SessionExpiredError(nil)
+		}
+		// Another call may have renewed the token already
+		if e.token == token {
+			if err := e.refresh(ctx); err != nil {
+				return "", err
+			}
+		}
+		return "Bearer " + e.token, nil
+	}
+}
+
+// clientWith returns the client authenticated with the highest-precedence
+// credentials. The caller holds e.mu.
+func (e *sessionEntry) clientWith(authorization string) *api.Client {
+	if e.token != "" {
+		authorization = "Bearer " + e.token
 	}
 	if authorization == "" {
-		client.Headers.Del("Authorization")
-		return
+		return e.client
 	}
+	client := e.client.Clone()
 	client.Headers.Set("Authorization", authorization)
+	return client
 }
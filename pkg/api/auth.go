@@ -0,0 +1,140 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrMFARequired is wrapped by the error returned by Login when the account
+// requires a TOTP code and none (or an invalid one) was given.
+var ErrMFARequired = errors.New("multi-factor authentication code required")
+
+// ErrSessionExpired is wrapped by errors returned when the PCE session token
+// expired (or was revoked) and could not be renewed.
+var ErrSessionExpired = errors.New("PCE session expired, call login to authenticate again")
+
+// ErrSessionRenewed is wrapped by errors returned when PCE rejected a request
+// that can't be replayed (e.g., a POST) because the session token expired. The
+// token has been renewed since; the request may be sent again.
+var ErrSessionRenewed = errors.New("PCE session expired and was renewed; the request was rejected and not repeated")
+
+// TokenRefreshBefore is how long before their expiry session tokens are
+// renewed.
+const TokenRefreshBefore = time.Minute
+
+type LoginResponse struct {
+	// Session token, sent as "Authorization: Bearer <token>"
+	Token string `json:"token"`
+	// Unix time (seconds) at which the token expires; 0 if unknown
+	ExpiresAt   int64 `json:"expires_at"`
+	MFARequired bool  `json:"mfa_required"`
+}
+
+// Expiry returns the token expiry, or the zero time if unknown.
+func (r *LoginResponse) Expiry() time.Time {
+	if r == nil || r.ExpiresAt <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(r.ExpiresAt, 0)
+}
+
+// Login exchanges a username and password (plus a TOTP code for accounts with
+// multi-factor authentication, else empty) for a PCE session token. The
+// credentials are never included in returned errors.
+func Login(ctx context.Context, c *Client, username, password, totp string) (*LoginResponse, *APIError) {
+	if username == "" || password == "" {
+		return nil, NewAPIError(400, "username and password are required")
+	}
+
+	path := "/v1/auth/login"
+
+	body := map[string]string{"username": username, "password": password}
+	if totp != "" {
+		body["totp"] = totp
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, NewAPIError(500, "failed to encode request payload")
+	}
+
+	var resp LoginResponse
+	if apiErr := c.Post(ctx, path, nil, bytes.NewReader(payload), &resp); apiErr != nil {
+		if apiErr.IsUnauthorized() && mfaRequired(apiErr) {
+			return nil, &APIError{Err: ErrMFARequired, Status: apiErr.Status, Message: apiErr.Message}
+		}
+		return nil, apiErr
+	}
+	if resp.MFARequired {
+		return nil, &APIError{Err: ErrMFARequired, Status: http.StatusUnauthorized, Message: ErrMFARequired.Error()}
+	}
+	if resp.Token == "" {
+		return nil, NewAPIError(502, "PCE returned no session token")
+	}
+	return &resp, nil
+}
+
+// mfaRequired reports whether a login rejection asks for a TOTP code.
+func mfaRequired(apiErr *APIError) bool {
+	msg := strings.ToLower(apiErr.Message)
+	return strings.Contains(msg, "totp") || strings.Contains(msg, "mfa")
+}
+
+// RefreshToken exchanges the session token the client authenticates with for
+// a new one, before or shortly after it expires.
+func RefreshToken(ctx context.Context, c *Client) (*LoginResponse, *APIError) {
+	path := "/v1/auth/refresh"
+
+	var resp LoginResponse
+	if apiErr := c.Post(ctx, path, nil, nil, &resp); apiErr != nil {
+		return nil, apiErr
+	}
+	if resp.Token == "" {
+		return nil, NewAPIError(502, "PCE returned no session token")
+	}
+	return &resp, nil
+}
+
+// SessionExpiredError returns the error reported when the session could not
+// be renewed because of err.
+func SessionExpiredError(err error) *APIError {
+	switch {
+	case err == nil:
+		err = ErrSessionExpired
+	case !errors.Is(err, ErrSessionExpired):
+		err = fmt.Errorf("%w (%v)", ErrSessionExpired, err)
+	}
+	return &APIError{Err: err, Status: http.StatusUnauthorized, Message: ErrSessionExpired.Error()}
+}
+
+// SessionRenewedError returns the error reported when apiErr, a 401, was
+// followed by a successful renewal of the session token.
+func SessionRenewedError(apiErr *APIError) *APIError {
+	return &APIError{Err: fmt.Errorf("%w (%v)", ErrSessionRenewed, apiErr), Status: http.StatusUnauthorized, Message: ErrSessionRenewed.Error(), Attempts: apiErr.Attempts}
+}
+
+// Logout invalidates the session token the client authenticates with.
+func Logout(ctx context.Context, c *Client) *APIError {
+	path := "/v1/auth/logout"
+	return c.Post(ctx, path, nil, nil, nil)
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is wrapped by errors returned while the circuit breaker is
+// open. Such requests are never sent, and never retried automatically.
+var ErrCircuitOpen = errors.New("PCE API unreachable (circuit breaker open)")
+
+type BreakerState string
+
+const (
+	BreakerStateClosed BreakerState = "closed"
+	BreakerStateOpen   BreakerState = "open"
+)
+
+const (
+	DefaultBreakerThreshold     = 5
+	DefaultBreakerProbeInterval = 5 * time.Second
+)
+
+// BreakerStatus is a snapshot of a CircuitBreaker for diagnostics.
+type BreakerStatus struct {
+	State               BreakerState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	LastError           string       `json:"last_error,omitempty"`
+	OpenedAt            *time.Time   `json:"opened_at,omitempty"`
+	LastProbeAt         *time.Time   `json:"last_probe_at,omitempty"`
+	LastProbeError      string       `json:"last_probe_error,omitempty"`
+}
+
+// CircuitBreaker fails requests fast after consecutive transport errors or 5xx
+// responses. While open, it probes the backend with an unauthenticated
+// RunHealthcheck every probe interval and closes again once a probe succeeds.
+// It may be shared between clients talking to the same PCE; Close stops
+// probing.
+type CircuitBreaker struct {
+	threshold     int
+	probeInterval time.Duration
+	closed        chan struct{}
+	closeOnce     sync.Once
+
+	mu           sync.Mutex
+	state        BreakerState
+	failures     int
+	lastErr      string
+	openedAt     time.Time
+	lastProbe    time.Time
+	lastProbeErr string
+}
+
+// NewCircuitBreaker returns a breaker that opens after threshold consecutive
+// failures. Returns nil (disabled) if threshold <= 0.
+func NewCircuitBreaker(threshold int, probeInterval time.Duration) *CircuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	if probeInterval <= 0 {
+		probeInterval = DefaultBreakerProbeInterval
+	}
+	return &CircuitBreaker{
+		threshold:     threshold,
+		probeInterval: probeInterval,
+		closed:        make(chan struct{}),
+		state:         BreakerStateClosed,
+	}
+}
+
+// Close stops background probing. The breaker stays in its current state.
+func (b *CircuitBreaker) Close() {
+	if b == nil {
+		return
+	}
+	b.closeOnce.Do(func() { close(b.closed) })
+}
+
+// Status returns a snapshot of the breaker.
+func (b *CircuitBreaker) Status() BreakerStatus {
+	if b == nil {
+		return BreakerStatus{State: BreakerStateClosed}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st := BreakerStatus{
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		LastError:           b.lastErr,
+		LastProbeError:      b.lastProbeErr,
+	}
+	if b.state == BreakerStateOpen {
+		openedAt := b.openedAt
+		st.OpenedAt = &openedAt
+	}
+	if !b.lastProbe.IsZero() {
+		lastProbe := b.lastProbe
+		st.LastProbeAt = &lastProbe
+	}
+	return st
+}
+
+type breakerProbeKey struct{}
+
+// allow fails fast while the breaker is open. Health probes bypass it.
+func (b *CircuitBreaker) allow(req *http.Request) *APIError {
+	if b == nil || req.Context().Value(breakerProbeKey{}) != nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state != BreakerStateOpen {
+		return nil
+	}
+	wait := b.probeInterval
+	if !b.lastProbe.IsZero() {
+		wait = max(0, time.Until(b.lastProbe.Add(b.probeInterval)))
+	}
+	return &APIError{
+		Err:        fmt.Errorf("%w: %s", ErrCircuitOpen, b.lastErr),
+		Status:     http.StatusServiceUnavailable,
+		Message:    "PCE API unreachable",
+		RetryAfter: wait,
+	}
+}
+
+// breakerFailure reports whether a response status (0 for transport errors)
+// indicates that PCE is unavailable. 501 and 505 are answers, not outages.
+func breakerFailure(status int) bool {
+	if status == 0 {
+		return true
+	}
+	return status >= 500 && status != http.StatusNotImplemented && status != http.StatusHTTPVersionNotSupported
+}
+
+// record updates the breaker with the outcome of a request sent by c, and
+// starts probing through c's transport when the breaker opens.
+func (b *CircuitBreaker) record(c *Client, req *http.Request, apiErr *APIError) {
+	if b == nil || req.Context().Value(breakerProbeKey{}) != nil {
+		return
+	}
+	// Only backend failures count; the caller giving up says nothing about PCE
+	failed := apiErr != nil && breakerFailure(apiErr.Status) && req.Context().Err() == nil
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !failed {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	b.lastErr = apiErr.Error()
+	if b.state == BreakerStateClosed && b.failures >= b.threshold {
+		b.state = BreakerStateOpen
+		b.openedAt = time.Now()
+		go b.probe(probeClient(c))
+	}
+}
+
+// probeClient returns a copy of c without credentials, so that probes don't
+// depend on a session token that may expire while PCE is down.
+func probeClient(c *Client) *Client {
+	probe := c.Clone()
+	probe.Headers.Del("Authorization")
+	probe.Headers.Del("Cookie")
+	probe.Reauthenticate = nil
+	probe.TokenExpiry = time.Time{}
+	return probe
+}
+
+// probe runs health checks until the backend recovers or b is closed.
+func (b *CircuitBreaker) probe(c *Client) {
+	ticker := time.NewTicker(b.probeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.closed:
+			return
+		case <-ticker.C:
+		}
+		ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), breakerProbeKey{}, true), b.probeInterval)
+		health, apiErr := RunHealthcheck(ctx, c, &RunHealthcheckArg{})
+		cancel()
+
+		b.mu.Lock()
+		b.lastProbe = time.Now()
+		switch {
+		case apiErr != nil:
+			b.lastProbeErr = apiErr.Error()
+		case !health.Healthy:
+			b.lastProbeErr = "node is not healthy"
+		default:
+			b.state = BreakerStateClosed
+			b.failures = 0
+			b.lastProbeErr = ""
+			b.mu.Unlock()
+			return
+		}
+		b.mu.Unlock()
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBreakerIgnoresNotImplemented(t *testing.T) {
+	b := NewCircuitBreaker(1, time.Hour)
+	defer b.Close()
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotImplemented)
+	}), WithCircuitBreaker(b))
+
+	for range 2 {
+		if apiErr := c.Get(context.Background(), "/v1/test", nil, nil); errors.Is(apiErr, ErrCircuitOpen) {
+			t.Fatal("breaker opened on 501 responses")
+		}
+	}
+}
+
+func TestBreakerProbesWithoutCredentials(t *testing.T) {
+	var down atomic.Bool
+	down.Store(true)
+	probeAuth := make(chan string, 1)
+	b := NewCircuitBreaker(1, 10*time.Millisecond)
+	defer b.Close()
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/healthcheck" {
+			select {
+			case probeAuth <- r.Header.Get("Authorization"):
+			default:
+			}
+			if down.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `{"healthy":true}`)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}), WithCircuitBreaker(b), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+	c.Headers.Set("Authorization", "Bearer expired")
+
+	c.Get(context.Background(), "/v1/test", nil, nil)
+	if apiErr := c.Get(context.Background(), "/v1/test", nil, nil); !errors.Is(apiErr, ErrCircuitOpen) {
+		t.Fatalf("got %v after a 503, want ErrCircuitOpen", apiErr)
+	}
+	if auth := <-probeAuth; auth != "" {
+		t.Errorf("probe sent Authorization %q, want none", auth)
+	}
+
+	down.Store(false)
+	deadline := time.Now().Add(5 * time.Second)
+	for b.Status().State != BreakerStateClosed {
+		if time.Now().After(deadline) {
+			t.Fatal("breaker did not close after a successful probe")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CacheRule sets the TTL of GET responses whose path (without the API prefix,
+// e.g. "/v1/nodes/node-1") matches Pattern, using path.Match syntax. A zero
+// TTL disables caching for matching paths.
+type CacheRule struct {
+	Pattern string
+	TTL     time.Duration
+}
+
+// DefaultCacheRules returns rules caching the mostly static organization,
+// cluster and node endpoints for ttl.
+func DefaultCacheRules(ttl time.Duration) []CacheRule {
+	patterns := []string{
+		"/v1/organizations",
+		"/v1/organizations/*",
+		"/v1/clusters/*/hardware",
+		"/v1/clusters/*/licensing",
+		"/v1/nodes/*",
+		"/v1/nodes/*/hardware",
+		"/v1/nodes/*/hardware/pci",
+		"/v1/nodes/*/license",
+	}
+	rules := make([]CacheRule, 0, len(patterns))
+	for _, p := range patterns {
+		rules = append(rules, CacheRule{Pattern: p, TTL: ttl})
+	}
+	return rules
+}
+
+// cacheDependencies lists, per resource collection, the other collections
+// whose responses embed it and must be invalidated along with it.
+var cacheDependencies = map[string][]string{
+	"/v1/instances":     {"/v1/nodes"},
+	"/v1/nodes":         {"/v1/organizations", "/v1/clusters"},
+	"/v1/clusters":      {"/v1/organizations"},
+	"/v1/organizations": {},
+	"/v1/users":         {},
+}
+
+const cacheMaxEntries = 1024
+
+type bypassCacheKey struct{}
+
+// WithoutCache makes GET requests made with the returned context skip cached
+// responses, e.g. for decisions that must not rely on stale data. Their
+// responses still refresh the cache.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+// bypassed reports whether req must not be served from the cache.
+func bypassed(req *http.Request) bool {
+	bypass, _ := req.Context().Value(bypassCacheKey{}).(bool)
+	return bypass
+}
+
+// CacheStats reports cache effectiveness for diagnostics.
+type CacheStats struct {
+	Hits        int64 `json:"hits"`
+	Misses      int64 `json:"misses"`
+	Revalidated int64 `json:"revalidated"`
+	Entries     int   `json:"entries"`
+}
+
+// Cache stores GET responses for a Client. Stale entries are revalidated with
+// If-None-Match/If-Modified-Since when PCE provided validators.
+type Cache struct {
+	rules []CacheRule
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+
+	hits        atomic.Int64
+	misses      atomic.Int64
+	revalidated atomic.Int64
+}
+
+// cacheEntry is immutable once stored.
+type cacheEntry struct {
+	path         string
+	body         []byte
+	etag         string
+	lastModified string
+	expires      time.Time
+}
+
+// NewCache creates a cache; the first matching rule wins.
+func NewCache(rules ...CacheRule) *Cache {
+	return &Cache{
+		rules:   rules,
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+// Stats returns a snapshot of the cache counters.
+func (c *Cache) Stats() CacheStats {
+	if c == nil {
+		return CacheStats{}
+	}
+	c.mu.Lock()
+	n := len(c.entries)
+	c.mu.Unlock()
+	return CacheStats{
+		Hits:        c.hits.Load(),
+		Misses:      c.misses.Load(),
+		Revalidated: c.revalidated.Load(),
+		Entries:     n,
+	}
+}
+
+// Purge drops all entries.
+func (c *Cache) Purge() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	clear(c.entries)
+	c.mu.Unlock()
+}
+
+// Invalidate drops entries for the resource collection of each path (e.g.
+// "/v1/instances/inst-1/power" -> "/v1/instances") and the collections that
+// embed it.
+func (c *Cache) Invalidate(paths ...string) {
+	if c == nil {
+		return
+	}
+	var prefixes []string
+	for _, p := range paths {
+		collection := resourceCollection(p)
+		prefixes = append(prefixes, collection)
+		prefixes = append(prefixes, cacheDependencies[collection]...)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, e := range c.entries {
+		for _, prefix := range prefixes {
+			if e.path == prefix || strings.HasPrefix(e.path, prefix+"/") {
+				delete(c.entries, key)
+				break
+			}
+		}
+	}
+}
+
+// ttl returns the TTL for path, or false if it should not be cached.
+func (c *Cache) ttl(p string) (time.Duration, bool) {
+	for _, r := range c.rules {
+		if ok, _ := path.Match(r.Pattern, p); ok {
+			return r.TTL, r.TTL > 0
+		}
+	}
+	return 0, false
+}
+
+func (c *Cache) get(key string) *cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries[key]
+}
+
+func (c *Cache) put(key string, e *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= cacheMaxEntries {
+		now := time.Now()
+		for k, old := range c.entries {
+			if now.After(old.expires) {
+				delete(c.entries, k)
+			}
+		}
+		// Still full: drop an arbitrary entry
+		for k := range c.entries {
+			if len(c.entries) < cacheMaxEntries {
+				break
+			}
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = e
+}
+
+// cacheKey identifies a GET by URL and caller identity, so that sessions
+// switching credentials never see each other's responses.
+func cacheKey(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Header.Get("Authorization") + "\x00" + req.Header.Get("Cookie")))
+	return hex.EncodeToString(sum[:8]) + " " + req.URL.String()
+}
+
+// resourceCollection returns the "/v1/<collection>" prefix of p.
+func resourceCollection(p string) string {
+	parts := strings.SplitN(strings.Trim(p, "/"), "/", 3)
+	if len(parts) < 2 {
+		return "/" + strings.Join(parts, "/")
+	}
+	return "/" + parts[0] + "/" + parts[1]
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testNode struct {
+	Name string `json:"name"`
+}
+
+func TestCacheRevalidatesWithETag(t *testing.T) {
+	var calls, notModified atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, `{"name":"node-1"}`)
+	}), WithCache(NewCache(CacheRule{Pattern: "/v1/nodes/*", TTL: time.Nanosecond})))
+
+	for i := range 2 {
+		var node testNode
+		if apiErr := c.Get(context.Background(), "/v1/nodes/node-1", nil, &node); apiErr != nil {
+			t.Fatalf("Get %d: %v", i, apiErr)
+		}
+		if node.Name != "node-1" {
+			t.Errorf("Get %d: got name %q, want node-1", i, node.Name)
+		}
+	}
+	if calls.Load() != 2 || notModified.Load() != 1 {
+		t.Errorf("got %d calls with %d not modified, want 2 with 1", calls.Load(), notModified.Load())
+	}
+	if stats := c.Cache.Stats(); stats.Revalidated != 1 {
+		t.Errorf("got %d revalidations, want 1", stats.Revalidated)
+	}
+}
+
+func TestCacheInvalidatesDependents(t *testing.T) {
+	var gets atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets.Add(1)
+		}
+		fmt.Fprint(w, `{"name":"node-1"}`)
+	}), WithCache(NewCache(DefaultCacheRules(time.Minute)...)))
+
+	get := func() {
+		t.Helper()
+		var node testNode
+		if apiErr := c.Get(context.Background(), "/v1/nodes/node-1", nil, &node); apiErr != nil {
+			t.Fatalf("Get: %v", apiErr)
+		}
+	}
+	get()
+	get()
+	if gets.Load() != 1 {
+		t.Fatalf("got %d GETs before the write, want 1", gets.Load())
+	}
+
+	// Instances are embedded in node responses
+	if apiErr := c.Post(context.Background(), "/v1/instances/inst-1/power", nil, nil, nil); apiErr != nil {
+		t.Fatalf("Post: %v", apiErr)
+	}
+	get()
+	if gets.Load() != 2 {
+		t.Errorf("got %d GETs after the write, want 2", gets.Load())
+	}
+}
+
+func TestResourceCollection(t *testing.T) {
+	for p, want := range map[string]string{
+		"/v1/instances/inst-1/power": "/v1/instances",
+		"/v1/nodes":                  "/v1/nodes",
+		"v1/nodes/node-1/":           "/v1/nodes",
+		"/v1":                        "/v1",
+	} {
+		if got := resourceCollection(p); got != want {
+			t.Errorf("resourceCollection(%q) = %q, want %q", p, got, want)
+		}
+	}
+}
+
+func TestCacheBypass(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"node-%d"}`, calls.Add(1))
+	}), WithCache(NewCache(DefaultCacheRules(time.Minute)...)))
+
+	get := func(ctx context.Context) string {
+		t.Helper()
+		var node testNode
+		if apiErr := c.Get(ctx, "/v1/nodes/node-1", nil, &node); apiErr != nil {
+			t.Fatalf("Get: %v", apiErr)
+		}
+		return node.Name
+	}
+	get(context.Background())
+	if name := get(WithoutCache(context.Background())); name != "node-2" {
+		t.Errorf("got %q bypassing the cache, want a fresh node-2", name)
+	}
+	// The fresh response replaced the cached one
+	if name := get(context.Background()); name != "node-2" || calls.Load() != 2 {
+		t.Errorf("got %q after %d calls, want the cached node-2 after 2", name, calls.Load())
+	}
+}
@@ -20,8 +20,10 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -34,12 +36,64 @@ type Client struct {
 	BaseURL   *url.URL
 	APIPrefix string
 	Headers   http.Header
+	Retry     RetryPolicy
+	// Timeouts of single attempts; see also WithRequestTimeout.
+	Timeouts Timeouts
+	// Optional GET response cache; nil disables caching.
+	Cache *Cache
+	// Client-side rate limits, checked before each attempt.
+	Limits RateLimits
+	// Optional circuit breaker, checked before each attempt.
+	Breaker *CircuitBreaker
+	// Maximum size of a response body in bytes; 0 means unlimited.
+	MaxResponseSize int64
+	// Optional callback renewing the credentials after a 401. It returns the
+	// new Authorization header, with which idempotent requests are replayed
+	// once; other requests fail with ErrSessionRenewed. Errors are reported
+	// as ErrSessionExpired.
+	Reauthenticate func(ctx context.Context) (authorization string, err error)
+	// Expiry of the credentials renewed by Reauthenticate, if known. Requests
+	// that can't be replayed renew them first when they expire within
+	// TokenRefreshBefore.
+	TokenExpiry time.Time
+	// Optional recorder of differences between GET responses and their types.
+	Schema *SchemaRecorder
+
+	// Resource-oriented services, set by New; replaceable with test doubles
+	Organizations OrganizationsService
+	Users         UsersService
+	Clusters      ClustersService
+	Nodes         NodesService
+	Instances     InstancesService
+	Snapshots     SnapshotsService
+	Tasks         TasksService
+
+	// Coalesces concurrent identical GETs; shared with clones
+	flights *flightGroup
 }
 
-// Construct a new Client.
+// Construct a new Client. Kept for compatibility; prefer New with options.
 func NewClient(baseURL string, insecureSkipVerify bool, timeout time.Duration, caCertPath string, customHeaders http.Header) (*Client, error) {
-	if insecureSkipVerify && caCertPath != "" {
-		return nil, WrapAPIError(fmt.Errorf("insecure skip verify and custom CA are mutually exclusive"), 0, "invalid TLS configuration")
+	return New(baseURL,
+		WithInsecureSkipVerify(insecureSkipVerify),
+		WithTimeout(timeout),
+		WithCACertFile(caCertPath),
+		WithHeaders(customHeaders),
+	)
+}
+
+// New constructs a new Client for the PCE API at baseURL, e.g.:
+//
+//	c, err := api.New("https://192.168.1.27:5007",
+//		api.WithTimeout(5*time.Second),
+//		api.WithMiddleware(loggingMiddleware),
+//	)
+func New(baseURL string, opts ...Option) (*Client, error) {
+	o := defaultClientOptions()
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
 	}
 
 	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
@@ -47,13 +101,67 @@ func NewClient(baseURL string, insecureSkipVerify bool, timeout time.Duration, c
 		return nil, WrapAPIError(err, 0, "invalid base URL")
 	}
 
+	transport := o.transport
+	if transport == nil {
+		t, apiErr := newTransport(&o)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		transport = t
+	} else if o.usesDefaultTransport() {
+		return nil, WrapAPIError(fmt.Errorf("custom transport cannot be combined with TLS options"), 0, "invalid transport configuration")
+	}
+
+	httpClient := &http.Client{
+		Transport: Chain(transport, o.middleware...),
+	}
+	c := &Client{
+		HTTP:      httpClient,
+		BaseURL:   u,
+		APIPrefix: o.apiPrefix,
+		Headers:   o.headers,
+		Retry:     o.retry,
+		Timeouts:  o.timeouts,
+		Cache:     o.cache,
+		Limits:    o.limits,
+		Breaker:   o.breaker,
+
+		MaxResponseSize: o.maxResponseSize,
+		Schema:          o.schema,
+
+		flights: &flightGroup{},
+	}
+	c.initServices()
+	return c, nil
+}
+
+// Clone returns a copy of c with its own Headers, e.g. to authenticate one
+// operation differently. The copy shares the transport, cache, rate limits,
+// circuit breaker and in-flight requests with c; its services are bound to
+// the copy.
+func (c *Client) Clone() *Client {
+	clone := *c
+	clone.Headers = c.Headers.Clone()
+	if clone.Headers == nil {
+		clone.Headers = make(http.Header)
+	}
+	clone.initServices()
+	return &clone
+}
+
+// newTransport builds the default transport from the TLS options.
+func newTransport(o *clientOptions) (*http.Transport, *APIError) {
+	if o.insecureSkipVerify && o.caCertPath != "" {
+		return nil, WrapAPIError(fmt.Errorf("insecure skip verify and custom CA are mutually exclusive"), 0, "invalid TLS configuration")
+	}
+
 	tlsConfig := &tls.Config{
-		InsecureSkipVerify: insecureSkipVerify,
+		InsecureSkipVerify: o.insecureSkipVerify,
 	}
 
 	// If CA cert path was provided, load and append to system cert pool.
-	if caCertPath != "" {
-		certPEM, err := os.ReadFile(caCertPath)
+	if o.caCertPath != "" {
+		certPEM, err := os.ReadFile(o.caCertPath)
 		if err != nil {
 			return nil, WrapAPIError(err, 0, "failed to read CA cert file")
 		}
@@ -69,24 +177,53 @@ func NewClient(baseURL string, insecureSkipVerify bool, timeout time.Duration, c
 		tlsConfig.InsecureSkipVerify = false
 	}
 
+	// Client certificate for mutual TLS, reloaded when the files change.
+	if o.clientCertPath != "" || o.clientKeyPath != "" {
+		if o.clientCertPath == "" || o.clientKeyPath == "" {
+			return nil, WrapAPIError(fmt.Errorf("client certificate and key must be provided together"), 0, "invalid TLS configuration")
+		}
+		reloader, err := newCertReloader(o.clientCertPath, o.clientKeyPath)
+		if err != nil {
+			return nil, WrapAPIError(err, 0, "invalid client certificate")
+		}
+		tlsConfig.GetClientCertificate = reloader.GetClientCertificate
+	}
+
 	transport := &http.Transport{
 		TLSClientConfig: tlsConfig,
+		Proxy:           http.ProxyFromEnvironment,
 	}
-	httpClient := &http.Client{Transport: transport, Timeout: timeout}
 
-	// Custom HTTP headers
-	headers := make(http.Header)
-	for k, vals := range customHeaders {
-		for _, v := range vals {
-			headers.Add(k, v)
+	if o.proxyURL != "" && o.unixSocket != "" {
+		return nil, WrapAPIError(fmt.Errorf("proxy and unix socket are mutually exclusive"), 0, "invalid transport configuration")
+	}
+
+	// Explicit proxy, overrides the environment
+	if o.proxyURL != "" {
+		proxy, err := url.Parse(o.proxyURL)
+		if err != nil {
+			// Don't wrap err, it would echo credentials embedded in the URL
+			return nil, NewAPIError(0, "invalid proxy URL")
+		}
+		switch proxy.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return nil, WrapAPIError(fmt.Errorf("unsupported proxy scheme %q", proxy.Scheme), 0, "invalid proxy URL")
 		}
+		transport.Proxy = http.ProxyURL(proxy)
 	}
-	return &Client{
-		HTTP:      httpClient,
-		BaseURL:   u,
-		APIPrefix: "/api",
-		Headers:   headers,
-	}, nil
+
+	// Unix domain socket, e.g. when running on a PCE node
+	if o.unixSocket != "" {
+		socket := o.unixSocket
+		dialer := &net.Dialer{}
+		transport.Proxy = nil
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", socket)
+		}
+	}
+
+	return transport, nil
 }
 
 // newURL builds the full URL for the request path (path may be "images" or "nodes/123").
@@ -127,8 +264,13 @@ func (c *Client) newRequest(ctx context.Context, method, path string, query url.
 		}
 	}
 
+	// Tie the request to the caller's operation
+	if id := RequestIDFromContext(ctx); id != "" {
+		req.Header.Set(RequestIDHeader, id)
+	}
+
 	// Authentication headers can be provided via c.Headers by callers.
-	// Avoid debug prints in production; use structured logging at call sites if needed.
+	// Traffic is logged (redacted) by the DebugLogging middleware, if installed.
 
 	// caller may set Content-Type when body is provided
 	return req, nil
@@ -136,16 +278,125 @@ func (c *Client) newRequest(ctx context.Context, method, path string, query url.
 
 // Do executes the request and decodes JSON into out if provided.
 // On non-2xx responses it tries to parse an APIError from the body.
+// Bodies larger than c.MaxResponseSize fail with ErrResponseTooLarge; JSON
+// arrays are decoded as a stream, and the elements read before the limit are
+// kept in out.
+// Failed attempts are retried according to c.Retry.
 func (c *Client) Do(req *http.Request, out any) *APIError {
+	_, apiErr := c.doWithRetry(req, out)
+	return apiErr
+}
+
+// doWithRetry implements Do and also returns the last response (with its body
+// already consumed and closed), if any.
+func (c *Client) doWithRetry(req *http.Request, out any) (*http.Response, *APIError) {
+	replayable := idempotent(req) && replayableBody(req)
+	if c.Reauthenticate != nil && !replayable && !c.TokenExpiry.IsZero() && time.Until(c.TokenExpiry) < TokenRefreshBefore {
+		// Don't let PCE reject a request that can't be replayed
+		authorization, err := c.Reauthenticate(req.Context())
+		if err != nil {
+			return nil, SessionExpiredError(err)
+		}
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, apiErr := c.doAttempts(req, out)
+	if !apiErr.IsUnauthorized() || c.Reauthenticate == nil {
+		return resp, apiErr
+	}
+
+	// Renew the credentials and replay once
+	authorization, err := c.Reauthenticate(req.Context())
+	if err != nil {
+		return resp, SessionExpiredError(err)
+	}
+	if !replayable {
+		// Renewed for the next requests only
+		return resp, SessionRenewedError(apiErr)
+	}
+	req.Header.Set("Authorization", authorization)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, apiErr
+		}
+		req.Body = body
+	}
+	return c.doAttempts(req, out)
+}
+
+// doAttempts performs req, retrying according to c.Retry.
+func (c *Client) doAttempts(req *http.Request, out any) (*http.Response, *APIError) {
+	canRetry := c.Retry.canRetry(req)
+	attempt := 0
+	for {
+		attempt++
+		resp, apiErr := c.do(req, out)
+		if apiErr == nil {
+			return resp, nil
+		}
+		apiErr.Attempts = attempt
+
+		// Retry transport errors and retryable statuses, unless the caller gave up
+		// or the request was stopped client-side
+		retryable := (apiErr.Status == 0 || retryableStatus(apiErr.Status)) &&
+			!errors.Is(apiErr, ErrRateLimited) && !errors.Is(apiErr, ErrCircuitOpen)
+		if !canRetry || !retryable || attempt >= c.Retry.MaxAttempts || req.Context().Err() != nil {
+			return resp, apiErr
+		}
+
+		wait := c.Retry.backoff(attempt)
+		if apiErr.RetryAfter > 0 {
+			wait = apiErr.RetryAfter
+		}
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return resp, apiErr
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, apiErr
+			}
+			req.Body = body
+		}
+	}
+}
+
+// do performs a single attempt. The response is returned (with its body
+// already consumed and closed) so that callers can inspect headers.
+func (c *Client) do(req *http.Request, out any) (*http.Response, *APIError) {
+	if apiErr := c.Breaker.allow(req); apiErr != nil {
+		return nil, apiErr
+	}
+	if apiErr := c.Limits.allow(req.Method); apiErr != nil {
+		return nil, apiErr
+	}
+
+	resp, apiErr := c.send(req, out)
+	c.Breaker.record(c, req, apiErr)
+	return resp, apiErr
+}
+
+// send performs the HTTP round trip and decodes the response.
+func (c *Client) send(req *http.Request, out any) (*http.Response, *APIError) {
+	// The timeout also covers reading the body
+	if timeout := c.Timeouts.forRequest(req); timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
 	resp, err := c.HTTP.Do(req)
 	if err != nil {
-		return WrapAPIError(err, 0, "request failed")
+		return nil, WrapAPIError(err, 0, "request failed")
 	}
 	defer resp.Body.Close()
 
 	// Handle error status codes.
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		data, _ := io.ReadAll(resp.Body)
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		wait, _ := retryAfter(resp)
 		// try decode APIError from body
 		var parsed APIError
 		if len(data) > 0 && json.Unmarshal(data, &parsed) == nil && (parsed.Status != 0 || parsed.Message != "") {
@@ -153,31 +404,114 @@ func (c *Client) Do(req *http.Request, out any) *APIError {
 			if parsed.Status == 0 {
 				parsed.Status = resp.StatusCode
 			}
-			return &parsed
+			parsed.RetryAfter = wait
+			return resp, &parsed
 		}
 		// fallback: raw body -> message
 		msg := strings.TrimSpace(string(data))
 		if msg == "" {
 			msg = resp.Status
 		}
-		return &APIError{Err: fmt.Errorf("%s", msg), Status: resp.StatusCode, Message: msg}
+		return resp, &APIError{Err: fmt.Errorf("%s", msg), Status: resp.StatusCode, Message: msg, RetryAfter: wait}
 	}
 
 	if out != nil {
-		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
-			return WrapAPIError(err, resp.StatusCode, "decoding response")
+		if err := readBody(resp.Body, c.MaxResponseSize, out); err != nil {
+			return resp, WrapAPIError(err, resp.StatusCode, "decoding response")
 		}
 	}
-	return nil
+	return resp, nil
 }
 
 // Get convenience helper to perform a GET and decode JSON response into out.
+// Responses are served from c.Cache when a cache rule matches path. Other
+// list responses are decoded as a stream rather than buffered, so they are
+// not coalesced, unless c.Schema needs the whole body.
 func (c *Client) Get(ctx context.Context, path string, query url.Values, out any) *APIError {
 	req, apiErr := c.newRequest(ctx, http.MethodGet, path, query, nil)
 	if apiErr != nil {
 		return apiErr
 	}
-	return c.Do(req, out)
+	if c.Cache != nil {
+		p := "/" + strings.TrimLeft(path, "/")
+		if ttl, ok := c.Cache.ttl(p); ok {
+			return c.cachedGet(req, p, ttl, out)
+		}
+	}
+	if isList(out) && c.Schema == nil {
+		return c.Do(req, out)
+	}
+	_, raw, apiErr := c.flights.do(req, c.doRaw)
+	if apiErr != nil {
+		return partialBody(raw, out, apiErr)
+	}
+	c.Schema.check(path, raw, out)
+	return decodeBody(raw, out)
+}
+
+// doRaw performs req with retries and returns the undecoded JSON body.
+func (c *Client) doRaw(req *http.Request) (*http.Response, json.RawMessage, *APIError) {
+	var raw json.RawMessage
+	resp, apiErr := c.doWithRetry(req, &raw)
+	return resp, raw, apiErr
+}
+
+// cachedGet serves a GET from the cache, revalidating stale entries.
+// Requests bypassing the cache are always sent, and refresh the entry.
+func (c *Client) cachedGet(req *http.Request, path string, ttl time.Duration, out any) *APIError {
+	key := cacheKey(req)
+	var entry *cacheEntry
+	if !bypassed(req) {
+		entry = c.Cache.get(key)
+	}
+	if entry != nil && time.Now().Before(entry.expires) {
+		c.Cache.hits.Add(1)
+		return decodeBody(entry.body, out)
+	}
+	if entry != nil {
+		if entry.etag != "" {
+			req.Header.Set("If-None-Match", entry.etag)
+		}
+		if entry.lastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.lastModified)
+		}
+	}
+
+	resp, raw, apiErr := c.flights.do(req, c.doRaw)
+	if apiErr != nil {
+		if entry != nil && apiErr.Status == http.StatusNotModified {
+			c.Cache.revalidated.Add(1)
+			c.Cache.hits.Add(1)
+			refreshed := *entry
+			refreshed.expires = time.Now().Add(ttl)
+			c.Cache.put(key, &refreshed)
+			return decodeBody(entry.body, out)
+		}
+		c.Cache.misses.Add(1)
+		return partialBody(raw, out, apiErr)
+	}
+	c.Cache.misses.Add(1)
+
+	c.Cache.put(key, &cacheEntry{
+		path:         path,
+		body:         raw,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		expires:      time.Now().Add(ttl),
+	})
+	c.Schema.check(path, raw, out)
+	return decodeBody(raw, out)
+}
+
+// decodeBody decodes a buffered JSON body into out.
+func decodeBody(body []byte, out any) *APIError {
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return WrapAPIError(err, http.StatusOK, "decoding response")
+	}
+	return nil
 }
 
 func (c *Client) Post(ctx context.Context, path string, query url.Values, body io.Reader, out any) *APIError {
@@ -188,6 +522,7 @@ func (c *Client) Post(ctx context.Context, path string, query url.Values, body i
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	defer c.Cache.Invalidate(path)
 	return c.Do(req, out)
 }
 
@@ -199,6 +534,7 @@ func (c *Client) Put(ctx context.Context, path string, query url.Values, body io
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	defer c.Cache.Invalidate(path)
 	return c.Do(req, out)
 }
 
@@ -207,5 +543,6 @@ func (c *Client) Delete(ctx context.Context, path string, query url.Values, out
 	if apiErr != nil {
 		return apiErr
 	}
+	defer c.Cache.Invalidate(path)
 	return c.Do(req, out)
 }
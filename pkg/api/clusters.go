@@ -49,12 +49,12 @@ type GetClusterLicensingByIdResponse struct {
 	Cluster struct {
 		Status     string `json:"status"`
 		NextExpiry string `json:"next_expiry"`
-	}
+	} `json:"cluster"`
 	// `node_id` -> { ok: bool, expiry: string }
 	Nodes map[string]struct {
 		Ok     bool   `json:"ok"`
 		Expiry string `json:"expiry"`
-	}
+	} `json:"nodes"`
 }
 
 func GetClusterLicensingById(ctx context.Context, c *Client, arg *GetClusterLicensingByIdArg) (*GetClusterLicensingByIdResponse, *APIError) {
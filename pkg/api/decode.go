@@ -0,0 +1,156 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+const (
+	// DefaultMaxResponseSize is the default limit on response bodies.
+	DefaultMaxResponseSize = 32 << 20
+	// Error bodies are only used for the error message.
+	maxErrorBodySize = 64 << 10
+)
+
+// ErrResponseTooLarge is wrapped by errors returned when a response body
+// exceeds Client.MaxResponseSize. List endpoints return the items decoded
+// before the limit along with the error.
+var ErrResponseTooLarge = errors.New("response too large")
+
+// IsResponseTooLarge reports whether the response exceeded the size limit,
+// i.e. the result (if any) is truncated.
+func (e *APIError) IsResponseTooLarge() bool {
+	return e != nil && errors.Is(e.Err, ErrResponseTooLarge)
+}
+
+// limitedReader reads at most limit bytes from r and fails with
+// ErrResponseTooLarge if more are available.
+type limitedReader struct {
+	r     io.Reader
+	limit int64
+	// Bytes left before overflowing, limit+1 initially
+	remaining int64
+}
+
+func newLimitedReader(r io.Reader, limit int64) io.Reader {
+	if limit <= 0 {
+		return r
+	}
+	return &limitedReader{r: r, limit: limit, remaining: limit + 1}
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		return 0, l.err()
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining == 0 {
+		// Drop the byte past the limit
+		return n - 1, l.err()
+	}
+	return n, err
+}
+
+func (l *limitedReader) err() error {
+	return fmt.Errorf("%w: exceeds %d bytes", ErrResponseTooLarge, l.limit)
+}
+
+// decodeStream decodes JSON from r into out. JSON arrays decoded into slices
+// are streamed element by element, so only one element is buffered at a time
+// and the elements decoded before an error are kept in out.
+func decodeStream(r io.Reader, out any) error {
+	if !isList(out) {
+		return json.NewDecoder(r).Decode(out)
+	}
+	v := reflect.ValueOf(out)
+
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		v.Elem().SetZero()
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("json: cannot unmarshal %v into Go value of type %s", tok, v.Elem().Type())
+	}
+
+	items := reflect.MakeSlice(v.Elem().Type(), 0, 0)
+	defer func() { v.Elem().Set(items) }()
+	for dec.More() {
+		item := reflect.New(items.Type().Elem())
+		if err := dec.Decode(item.Interface()); err != nil {
+			return err
+		}
+		items = reflect.Append(items, item.Elem())
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// isList reports whether out points to a slice decodeStream decodes element
+// by element, i.e. not a byte slice such as json.RawMessage.
+func isList(out any) bool {
+	v := reflect.ValueOf(out)
+	return v.Kind() == reflect.Pointer && !v.IsNil() && v.Elem().Kind() == reflect.Slice && v.Elem().Type().Elem().Kind() != reflect.Uint8
+}
+
+// readBody reads a success response body into out, enforcing limit. Raw
+// messages are read verbatim; anything else is decoded with decodeStream.
+func readBody(r io.Reader, limit int64, out any) error {
+	r = newLimitedReader(r, limit)
+	raw, ok := out.(*json.RawMessage)
+	if !ok {
+		return decodeStream(r, out)
+	}
+	data, err := io.ReadAll(r)
+	*raw = data
+	if err == nil && !json.Valid(data) {
+		// Same error json.Decoder reports
+		return json.Unmarshal(data, new(any))
+	}
+	return err
+}
+
+// partialBody decodes the items of a truncated list response, if out is a
+// slice. The error of a truncated response is returned unchanged.
+func partialBody(raw json.RawMessage, out any, apiErr *APIError) *APIError {
+	if apiErr.IsResponseTooLarge() && out != nil && len(raw) > 0 {
+		_ = decodeStream(bytes.NewReader(raw), out)
+	}
+	return apiErr
+}
+
+// partialList returns the items decoded before a truncated list response
+// along with apiErr, or nil for other errors.
+func partialList[T any](resp *[]T, apiErr *APIError) (*[]T, *APIError) {
+	if apiErr.IsResponseTooLarge() {
+		return resp, apiErr
+	}
+	return nil, apiErr
+}
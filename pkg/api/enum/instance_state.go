@@ -0,0 +1,44 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package enum
+
+type InstanceState string
+
+const (
+	InstanceStateRunning  InstanceState = "running"
+	InstanceStateStopped  InstanceState = "stopped"
+	InstanceStatePaused   InstanceState = "paused"
+	InstanceStateStarting InstanceState = "starting"
+	InstanceStateStopping InstanceState = "stopping"
+	InstanceStateUnknown  InstanceState = "unknown"
+)
+
+func (s InstanceState) IsValid() bool {
+	switch s {
+	case InstanceStateRunning, InstanceStateStopped, InstanceStatePaused, InstanceStateStarting, InstanceStateStopping, InstanceStateUnknown:
+		return true
+	}
+	return false
+}
+
+// IsStopped reports whether the instance is powered off.
+func (s InstanceState) IsStopped() bool {
+	return s == InstanceStateStopped
+}
+
+func (s InstanceState) String() string {
+	return string(s)
+}
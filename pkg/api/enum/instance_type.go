@@ -24,6 +24,23 @@ const (
 	InstanceTypeEnumPodman                         // 3
 )
 
+func (e InstanceTypeEnum) IsValid() bool {
+	return e >= InstanceTypeEnumDocker && e <= InstanceTypeEnumPodman
+}
+
+// ParseInstanceType returns the type named s, e.g. "qemu".
+func ParseInstanceType(s string) (InstanceTypeEnum, bool) {
+	for e := InstanceTypeEnumDocker; e <= InstanceTypeEnumPodman; e++ {
+		if e.String() == s {
+			return e, true
+		}
+	}
+	return 0, false
+}
+
 func (e InstanceTypeEnum) String() string {
+	if !e.IsValid() {
+		return "unknown"
+	}
 	return [...]string{"docker", "lxc", "qemu", "podman"}[e]
 }
@@ -0,0 +1,47 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package enum
+
+type TaskState string
+
+const (
+	TaskStatePending   TaskState = "pending"
+	TaskStateRunning   TaskState = "running"
+	TaskStateSucceeded TaskState = "succeeded"
+	TaskStateFailed    TaskState = "failed"
+	TaskStateCancelled TaskState = "cancelled"
+)
+
+func (s TaskState) IsValid() bool {
+	switch s {
+	case TaskStatePending, TaskStateRunning, TaskStateSucceeded, TaskStateFailed, TaskStateCancelled:
+		return true
+	}
+	return false
+}
+
+// IsTerminal reports whether the task has finished, successfully or not.
+func (s TaskState) IsTerminal() bool {
+	switch s {
+	case TaskStateSucceeded, TaskStateFailed, TaskStateCancelled:
+		return true
+	}
+	return false
+}
+
+func (s TaskState) String() string {
+	return string(s)
+}
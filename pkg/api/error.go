@@ -15,11 +15,27 @@ limitations under the License.
 */
 package api
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
 // API error struct.
 type APIError struct {
 	Err     error  `json:"-"` // not serialized
 	Status  int    `json:"code"`
 	Message string `json:"message"`
+	// Number of attempts made before giving up (0 if the request was never sent).
+	Attempts int `json:"-"`
+	// Suggested delay before retrying, from Retry-After or a client-side rate limit.
+	RetryAfter time.Duration `json:"-"`
+	// X-Request-ID PCE saw, when the request was shared with another caller
+	// (see flightGroup); empty if it is the caller's own.
+	RequestID string `json:"-"`
 }
 
 var _ error = (*APIError)(nil) // compile-time check
@@ -29,10 +45,14 @@ func (e *APIError) Error() string {
 	if e == nil {
 		return "<nil>"
 	}
+	msg := e.Message
 	if e.Err != nil {
-		return e.Err.Error()
+		msg = e.Err.Error()
+	}
+	if e.Attempts > 1 {
+		msg = fmt.Sprintf("%s (after %d attempts)", msg, e.Attempts)
 	}
-	return e.Message
+	return msg
 }
 
 // Returns the underlying error for use with errors.Is / errors.As.
@@ -60,3 +80,65 @@ func WrapAPIError(err error, status int, message string) *APIError {
 		Message: message,
 	}
 }
+
+// IsBadRequest reports whether PCE rejected the request as malformed (400).
+func (e *APIError) IsBadRequest() bool {
+	return e != nil && e.Status == http.StatusBadRequest
+}
+
+// IsUnauthorized reports whether the credentials are missing, invalid or expired (401).
+func (e *APIError) IsUnauthorized() bool {
+	return e != nil && e.Status == http.StatusUnauthorized
+}
+
+// IsForbidden reports whether the authenticated user lacks permission (403).
+func (e *APIError) IsForbidden() bool {
+	return e != nil && e.Status == http.StatusForbidden
+}
+
+// IsNotFound reports whether the requested resource does not exist (404).
+func (e *APIError) IsNotFound() bool {
+	return e != nil && e.Status == http.StatusNotFound
+}
+
+// IsConflict reports whether the request conflicts with the resource state (409).
+func (e *APIError) IsConflict() bool {
+	return e != nil && e.Status == http.StatusConflict
+}
+
+// IsRateLimited reports whether PCE throttled the request (429).
+func (e *APIError) IsRateLimited() bool {
+	return e != nil && e.Status == http.StatusTooManyRequests
+}
+
+// IsTimeout reports whether the request timed out, either on the client side
+// (context deadline, transport timeout) or as reported by PCE or a gateway.
+func (e *APIError) IsTimeout() bool {
+	if e == nil {
+		return false
+	}
+	if e.Status == http.StatusRequestTimeout || e.Status == http.StatusGatewayTimeout {
+		return true
+	}
+	if errors.Is(e.Err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(e.Err, &netErr) && netErr.Timeout()
+}
+
+// IsRetryable reports whether repeating the same request later may succeed.
+func (e *APIError) IsRetryable() bool {
+	if e == nil {
+		return false
+	}
+	if errors.Is(e, ErrSessionRenewed) {
+		// Rejected before the token was renewed
+		return true
+	}
+	if e.Status == 0 {
+		// Transport error; the caller giving up is final
+		return e.Err != nil && !errors.Is(e.Err, context.Canceled)
+	}
+	return e.Status == http.StatusRequestTimeout || retryableStatus(e.Status)
+}
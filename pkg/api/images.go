@@ -17,10 +17,12 @@ package api
 
 import (
 	"context"
+	"net/url"
 )
 
 type ListImagesByNodeArg struct {
 	NodeId string
+	PageArg
 }
 type ListImagesByNodeResponse = []ImageList
 
@@ -31,9 +33,12 @@ func ListImagesByNode(ctx context.Context, c *Client, arg *ListImagesByNodeArg)
 
 	path := c.ExpandPath("/v1/nodes/{node_id}/images/images", map[string]string{"node_id": arg.NodeId})
 
+	query := make(url.Values)
+	arg.PageArg.apply(query)
+
 	var resp ListImagesByNodeResponse
-	if apiErr := c.Get(ctx, path, nil, &resp); apiErr != nil {
-		return nil, apiErr
+	if apiErr := c.Get(ctx, path, query, &resp); apiErr != nil {
+		return partialList(&resp, apiErr)
 	}
 	return &resp, nil
 }
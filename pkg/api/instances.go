@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/url"
+	"strconv"
 
 	"github.com/PextraCloud/pce-mcp/pkg/api/enum"
 )
@@ -28,6 +29,7 @@ type GetInstancesByIdArg struct {
 	// Either `NodeId` or `ClusterId` must be provided
 	NodeId    string
 	ClusterId string
+	PageArg
 }
 type GetInstancesByIdResponse = []InstanceList
 
@@ -47,11 +49,171 @@ func GetInstancesById(ctx context.Context, c *Client, arg *GetInstancesByIdArg)
 	} else {
 		return nil, NewAPIError(400, "either node_id or cluster_id is required")
 	}
+	arg.PageArg.apply(query)
 
 	path := "/v1/instances"
 
 	var resp GetInstancesByIdResponse
 	if apiErr := c.Get(ctx, path, query, &resp); apiErr != nil {
+		return partialList(&resp, apiErr)
+	}
+	return &resp, nil
+}
+
+type GetInstanceByIdArg struct {
+	InstanceId string
+	NodeId     string
+}
+type GetInstanceByIdResponse = InstanceDetail
+
+func GetInstanceById(ctx context.Context, c *Client, arg *GetInstanceByIdArg) (*GetInstanceByIdResponse, *APIError) {
+	if arg == nil || arg.NodeId == "" || arg.InstanceId == "" {
+		return nil, NewAPIError(400, "node_id and instance_id are required")
+	}
+
+	path := c.ExpandPath("/v1/instances/{instance_id}", map[string]string{"instance_id": arg.InstanceId})
+
+	query := make(url.Values)
+	query.Set("node_id", arg.NodeId)
+
+	var resp GetInstanceByIdResponse
+	if apiErr := c.Get(ctx, path, query, &resp); apiErr != nil {
+		return nil, apiErr
+	}
+	return &resp, nil
+}
+
+type CreateInstanceArg struct {
+	NodeId string                `json:"-"`
+	Name   string                `json:"name"`
+	Type   enum.InstanceTypeEnum `json:"type"`
+	// Image name, as listed by ListImagesByNode; its type must match Type
+	Image string `json:"image"`
+	// Storage pool holding the instance volumes
+	StoragePoolId string      `json:"storage_pool_id"`
+	Cpu           InstanceCpu `json:"cpu"`
+	MemoryMB      int         `json:"memory"`
+	Autostart     bool        `json:"autostart"`
+	BootOrder     int         `json:"boot_order"`
+}
+type CreateInstanceResponse struct {
+	InstanceId string `json:"instance_id"`
+	TaskId     string `json:"task_id"`
+}
+
+// CreateInstance starts creating an instance; track it with the returned task.
+// See CheckCreateInstance to check the request against the node first.
+func CreateInstance(ctx context.Context, c *Client, arg *CreateInstanceArg) (*CreateInstanceResponse, *APIError) {
+	if apiErr := arg.validate(); apiErr != nil {
+		return nil, apiErr
+	}
+
+	path := "/v1/instances"
+
+	query := make(url.Values)
+	query.Set("node_id", arg.NodeId)
+
+	payload, err := json.Marshal(arg)
+	if err != nil {
+		return nil, NewAPIError(500, "failed to encode request payload")
+	}
+
+	var resp CreateInstanceResponse
+	if apiErr := c.Post(ctx, path, query, bytes.NewReader(payload), &resp); apiErr != nil {
+		return nil, apiErr
+	}
+	return &resp, nil
+}
+
+func (arg *CreateInstanceArg) validate() *APIError {
+	switch {
+	case arg == nil || arg.NodeId == "" || arg.Name == "" || arg.Image == "" || arg.StoragePoolId == "":
+		return NewAPIError(400, "node_id, name, image and storage_pool_id are required")
+	case !arg.Type.IsValid():
+		return NewAPIError(400, "invalid type")
+	case arg.Cpu.Sockets < 1 || arg.Cpu.Cores < 1 || arg.Cpu.Threads < 1:
+		return NewAPIError(400, "cpu sockets, cores and threads must be at least 1")
+	case arg.MemoryMB < 1:
+		return NewAPIError(400, "memory is required")
+	}
+	return nil
+}
+
+type DeleteInstanceArg struct {
+	InstanceId string
+	NodeId     string
+	// Also delete the volumes attached to the instance
+	DeleteVolumes bool
+	// Delete the instance even if it is not stopped
+	Force bool
+}
+type DeleteInstanceResponse struct {
+	TaskId string `json:"task_id"`
+}
+
+// DeleteInstance starts deleting an instance; track it with the returned task.
+// See CheckDeleteInstance to check that the instance is stopped first. Like
+// POST requests, it is never replayed: a replay would fail with a 404 once
+// the first attempt went through.
+func DeleteInstance(ctx context.Context, c *Client, arg *DeleteInstanceArg) (*DeleteInstanceResponse, *APIError) {
+	if arg == nil || arg.NodeId == "" || arg.InstanceId == "" {
+		return nil, NewAPIError(400, "node_id and instance_id are required")
+	}
+
+	path := c.ExpandPath("/v1/instances/{instance_id}", map[string]string{"instance_id": arg.InstanceId})
+
+	query := make(url.Values)
+	query.Set("node_id", arg.NodeId)
+	query.Set("delete_volumes", strconv.FormatBool(arg.DeleteVolumes))
+	query.Set("force", strconv.FormatBool(arg.Force))
+
+	var resp DeleteInstanceResponse
+	if apiErr := c.Delete(withoutReplay(ctx), path, query, &resp); apiErr != nil {
+		return nil, apiErr
+	}
+	return &resp, nil
+}
+
+type MigrateInstanceArg struct {
+	InstanceId string `json:"-"`
+	// Source node
+	NodeId       string `json:"-"`
+	TargetNodeId string `json:"target_node_id"`
+	// Migrate while running, instead of stopped
+	Live bool `json:"live"`
+	// Storage pool on the target node to move the volumes to; empty keeps
+	// them where they are (shared storage)
+	TargetStoragePoolId string `json:"target_storage_pool_id,omitempty"`
+	// Migrate live despite CPU incompatibilities (see CheckMigrateInstance)
+	IgnoreCpuWarnings bool `json:"-"`
+}
+type MigrateInstanceResponse struct {
+	TaskId string `json:"task_id"`
+}
+
+// MigrateInstance starts moving an instance to another node of its cluster;
+// track it with the returned task. See CheckMigrateInstance to check the
+// target node first.
+func MigrateInstance(ctx context.Context, c *Client, arg *MigrateInstanceArg) (*MigrateInstanceResponse, *APIError) {
+	if arg == nil || arg.NodeId == "" || arg.InstanceId == "" || arg.TargetNodeId == "" {
+		return nil, NewAPIError(400, "node_id, instance_id and target_node_id are required")
+	}
+	if arg.NodeId == arg.TargetNodeId {
+		return nil, NewAPIError(400, "target_node_id must differ from node_id")
+	}
+
+	path := c.ExpandPath("/v1/instances/{instance_id}/migrate", map[string]string{"instance_id": arg.InstanceId})
+
+	query := make(url.Values)
+	query.Set("node_id", arg.NodeId)
+
+	payload, err := json.Marshal(arg)
+	if err != nil {
+		return nil, NewAPIError(500, "failed to encode request payload")
+	}
+
+	var resp MigrateInstanceResponse
+	if apiErr := c.Post(ctx, path, query, bytes.NewReader(payload), &resp); apiErr != nil {
 		return nil, apiErr
 	}
 	return &resp, nil
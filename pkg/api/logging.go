@@ -0,0 +1,185 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxLoggedBody is the maximum number of body bytes included in debug logs.
+const maxLoggedBody = 2048
+
+// maxRedactedBody is the maximum size of a body read for logging. Larger
+// bodies are not logged, as a truncated JSON body can't be redacted.
+const maxRedactedBody = 1 << 20
+
+const redacted = "REDACTED"
+
+// redactedHeaders are never logged verbatim.
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// redactedFields are JSON fields (and query parameters) never logged verbatim,
+// e.g. license keys, node SSH keys and client certificates.
+var redactedFields = map[string]bool{
+	"key":          true,
+	"license_key":  true,
+	"ssh_key":      true,
+	"client_cert":  true,
+	"password":     true,
+	"totp":         true,
+	"token":        true,
+	"access_token": true,
+	"secret":       true,
+}
+
+// DebugLogging returns middleware that logs method, URL, status, latency and
+// truncated request/response bodies at debug level, with credentials and
+// secrets redacted. It is a no-op unless logger has debug enabled.
+func DebugLogging(logger *slog.Logger) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+			if logger == nil || !logger.Enabled(ctx, slog.LevelDebug) {
+				return next.RoundTrip(req)
+			}
+
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("url", redactURL(req.URL)),
+				slog.Any("headers", redactHeaders(req.Header)),
+			}
+			if id := req.Header.Get(RequestIDHeader); id != "" {
+				attrs = append(attrs, slog.String("request_id", id))
+			}
+			if req.GetBody != nil {
+				if body, err := req.GetBody(); err == nil {
+					data, _ := io.ReadAll(io.LimitReader(body, maxRedactedBody+1))
+					body.Close()
+					attrs = append(attrs, slog.String("request_body", loggedBody(data)))
+				}
+			}
+
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			attrs = append(attrs, slog.Duration("latency", time.Since(start)))
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+				logger.LogAttrs(ctx, slog.LevelDebug, "PCE API request failed", attrs...)
+				return resp, err
+			}
+
+			// Buffer the start of the body so it can be both logged and
+			// consumed by the caller
+			data, readErr := io.ReadAll(io.LimitReader(resp.Body, maxRedactedBody+1))
+			resp.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(data), resp.Body), resp.Body}
+			attrs = append(attrs,
+				slog.Int("status", resp.StatusCode),
+				slog.String("response_body", loggedBody(data)),
+			)
+			if readErr != nil {
+				attrs = append(attrs, slog.String("error", readErr.Error()))
+			}
+			logger.LogAttrs(ctx, slog.LevelDebug, "PCE API request", attrs...)
+			return resp, nil
+		})
+	}
+}
+
+func redactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	for _, name := range redactedHeaders {
+		if out.Get(name) != "" {
+			out.Set(name, redacted)
+		}
+	}
+	return out
+}
+
+func redactURL(u *url.URL) string {
+	c := *u
+	if c.User != nil {
+		c.User = url.User(redacted)
+	}
+	q := c.Query()
+	changed := false
+	for k := range q {
+		if redactedFields[strings.ToLower(k)] {
+			q.Set(k, redacted)
+			changed = true
+		}
+	}
+	if changed {
+		c.RawQuery = q.Encode()
+	}
+	return c.String()
+}
+
+// loggedBody redacts data, the start of a body read for logging, unless it
+// is incomplete.
+func loggedBody(data []byte) string {
+	if len(data) > maxRedactedBody {
+		return fmt.Sprintf("(more than %d bytes, not logged)", maxRedactedBody)
+	}
+	return redactBody(data)
+}
+
+// redactBody redacts secret fields of JSON bodies and truncates the result.
+// Non-JSON bodies are only truncated.
+func redactBody(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	out := data
+	var v any
+	if json.Unmarshal(data, &v) == nil {
+		if b, err := json.Marshal(redactValue(v)); err == nil {
+			out = b
+		}
+	}
+	if len(out) > maxLoggedBody {
+		return string(out[:maxLoggedBody]) + "...(truncated)"
+	}
+	return string(out)
+}
+
+func redactValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			if redactedFields[strings.ToLower(k)] {
+				t[k] = redacted
+			} else {
+				t[k] = redactValue(val)
+			}
+		}
+	case []any:
+		for i := range t {
+			t[i] = redactValue(t[i])
+		}
+	}
+	return v
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import "net/http"
+
+// Middleware wraps a RoundTripper, e.g. to add logging, tracing or to stub
+// responses in tests.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to the http.RoundTripper interface.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Chain wraps rt with mw so that mw[0] is the outermost middleware.
+func Chain(rt http.RoundTripper, mw ...Middleware) http.RoundTripper {
+	for i := len(mw) - 1; i >= 0; i-- {
+		if mw[i] != nil {
+			rt = mw[i](rt)
+		}
+	}
+	return rt
+}
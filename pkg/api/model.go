@@ -71,20 +71,75 @@ type ClusterList struct {
 }
 
 type InstanceList struct {
-	Id     string `json:"id"`
-	NodeId string `json:"node_id"`
-	Type   int    `json:"type"`
-	Name   string `json:"name"`
-	Cpu    struct {
-		Sockets int `json:"sockets"`
-		Cores   int `json:"cores"`
-		Threads int `json:"threads"`
-	} `json:"cpu"`
-	Vcpus     int    `json:"vcpus"`
-	Memory    int    `json:"memory"`
-	Creation  string `json:"creation"`
-	Autostart bool   `json:"autostart"`
-	BootOrder int    `json:"boot_order"`
+	Id        string      `json:"id"`
+	NodeId    string      `json:"node_id"`
+	Type      int         `json:"type"`
+	Name      string      `json:"name"`
+	Cpu       InstanceCpu `json:"cpu"`
+	Vcpus     int         `json:"vcpus"`
+	Memory    int         `json:"memory"`
+	Creation  string      `json:"creation"`
+	Autostart bool        `json:"autostart"`
+	BootOrder int         `json:"boot_order"`
+}
+
+type InstanceCpu struct {
+	Sockets int `json:"sockets"`
+	Cores   int `json:"cores"`
+	Threads int `json:"threads"`
+}
+
+// Vcpus returns the number of vCPUs of the topology.
+func (c InstanceCpu) Vcpus() int {
+	return c.Sockets * c.Cores * c.Threads
+}
+
+type InstanceDetail struct {
+	InstanceList
+	Description string         `json:"description"`
+	Disks       []InstanceDisk `json:"disks"`
+	Nics        []InstanceNic  `json:"nics"`
+	// Runtime state
+	Status struct {
+		State enum.InstanceState `json:"state"`
+		// Seconds since the instance was started, 0 if not running
+		Uptime       int64   `json:"uptime"`
+		CpuPercent   float64 `json:"cpu_percent"`
+		MemoryUsedMB int64   `json:"memory_used"`
+	} `json:"status"`
+}
+
+type InstanceDisk struct {
+	Id            string  `json:"id"`
+	Name          string  `json:"name"`
+	Bus           string  `json:"bus"`
+	SizeGB        float64 `json:"size"`
+	StoragePoolId string  `json:"storage_pool_id"`
+	ReadOnly      bool    `json:"read_only"`
+}
+
+type InstanceNic struct {
+	Id         string `json:"id"`
+	Model      string `json:"model"`
+	MacAddress string `json:"mac_address"`
+	Network    string `json:"network"`
+	// Reported by the guest agent, if any
+	IpAddresses []string `json:"ip_addresses"`
+}
+
+type SnapshotDetail struct {
+	Id          string `json:"id"`
+	InstanceId  string `json:"instance_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Creation    string `json:"creation"`
+	// Snapshot this one was taken from, empty for the first
+	ParentId string `json:"parent_id"`
+	SizeMB   int64  `json:"size"`
+	// Includes the memory state (QEMU only)
+	IncludesMemory bool `json:"memory"`
+	// The instance currently derives from this snapshot
+	Current bool `json:"current"`
 }
 
 type StoragePoolDetail struct {
@@ -110,3 +165,28 @@ type ImageList struct {
 	Type          enum.InstanceTypeEnum `json:"type"`
 	StoragePoolId string                `json:"storage_pool_id"`
 }
+
+type TaskDetail struct {
+	Id         string         `json:"id"`
+	NodeId     string         `json:"node_id"`
+	Type       string         `json:"type"`
+	State      enum.TaskState `json:"state"`
+	Progress   float64        `json:"progress"`
+	Error      string         `json:"error"`
+	ResourceId string         `json:"resource_id"`
+	Creation   string         `json:"creation"`
+	Started    string         `json:"started"`
+	Finished   string         `json:"finished"`
+}
+
+type AuditLogEntry struct {
+	Id             string `json:"id"`
+	OrganizationId string `json:"organization_id"`
+	UserId         string `json:"user_id"`
+	Username       string `json:"username"`
+	Action         string `json:"action"`
+	ResourceId     string `json:"resource_id"`
+	Details        string `json:"details"`
+	IpAddress      string `json:"ip_address"`
+	Time           string `json:"time"`
+}
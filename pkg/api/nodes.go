@@ -65,6 +65,17 @@ type GetNodeHardwareByIdResponse struct {
 	Usb    []NodeHardwareUsb    `json:"usb"`
 }
 
+// MemoryMB returns the total size of the installed memory in MB.
+func (r *GetNodeHardwareByIdResponse) MemoryMB() int {
+	total := 0
+	for _, bank := range r.Memory {
+		if !bank.Empty {
+			total += bank.Data.Size
+		}
+	}
+	return total
+}
+
 func GetNodeHardwareById(ctx context.Context, c *Client, arg *GetNodeHardwareByIdArg) (*GetNodeHardwareByIdResponse, *APIError) {
 	if arg == nil || arg.NodeId == "" {
 		return nil, NewAPIError(400, "node_id is required")
@@ -79,16 +90,15 @@ func GetNodeHardwareById(ctx context.Context, c *Client, arg *GetNodeHardwareByI
 	return &resp, nil
 }
 
-type GetNodeLicenseByIdArg struct {
-	NodeId string
-}
+// GetNodeLicenseByIdArg is an alias so that GetNodeLicenseById accepts both names.
+type GetNodeLicenseByIdArg = GetNodeByIdArg
 type GetNodeLicenseByIdResponse struct {
 	Key    string `json:"key"`
 	Expiry string `json:"expiry"`
 	Valid  bool   `json:"valid"`
 }
 
-func GetNodeLicenseById(ctx context.Context, c *Client, arg *GetNodeByIdArg) (*GetNodeLicenseByIdResponse, *APIError) {
+func GetNodeLicenseById(ctx context.Context, c *Client, arg *GetNodeLicenseByIdArg) (*GetNodeLicenseByIdResponse, *APIError) {
 	if arg == nil || arg.NodeId == "" {
 		return nil, NewAPIError(400, "node_id is required")
 	}
@@ -116,7 +126,7 @@ func GetNodeStoragePoolsById(ctx context.Context, c *Client, arg *GetNodeStorage
 
 	var resp GetNodeStoragePoolsByIdResponse
 	if apiErr := c.Get(ctx, path, nil, &resp); apiErr != nil {
-		return nil, apiErr
+		return partialList(&resp, apiErr)
 	}
 	return &resp, nil
 }
@@ -135,7 +145,7 @@ func GetNodePciDevicesById(ctx context.Context, c *Client, arg *GetNodePciDevice
 
 	var resp GetNodePciDevicesByIdResponse
 	if apiErr := c.Get(ctx, path, nil, &resp); apiErr != nil {
-		return nil, apiErr
+		return partialList(&resp, apiErr)
 	}
 	return &resp, nil
 }
@@ -19,6 +19,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"net/url"
 )
 
 type ListOrganizationsArg struct{}
@@ -29,7 +30,7 @@ func ListOrganizations(ctx context.Context, c *Client, arg *ListOrganizationsArg
 
 	var resp ListOrganizationsResponse
 	if apiErr := c.Get(ctx, path, nil, &resp); apiErr != nil {
-		return nil, apiErr
+		return partialList(&resp, apiErr)
 	}
 	return &resp, nil
 }
@@ -98,3 +99,25 @@ func DeleteOrganizationById(ctx context.Context, c *Client, arg *DeleteOrganizat
 	}
 	return &resp, nil
 }
+
+type ListOrganizationAuditLogsByIdArg struct {
+	OrganizationId string
+	PageArg
+}
+type ListOrganizationAuditLogsByIdResponse = []AuditLogEntry
+
+func ListOrganizationAuditLogsById(ctx context.Context, c *Client, arg *ListOrganizationAuditLogsByIdArg) (*ListOrganizationAuditLogsByIdResponse, *APIError) {
+	if arg == nil || arg.OrganizationId == "" {
+		return nil, NewAPIError(400, "organization_id is required")
+	}
+
+	path := c.ExpandPath("/v1/organizations/{organization_id}/audit-logs", map[string]string{"organization_id": arg.OrganizationId})
+	query := make(url.Values)
+	arg.PageArg.apply(query)
+
+	var resp ListOrganizationAuditLogsByIdResponse
+	if apiErr := c.Get(ctx, path, query, &resp); apiErr != nil {
+		return partialList(&resp, apiErr)
+	}
+	return &resp, nil
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"iter"
+	"maps"
+	"net/url"
+	"strconv"
+	"sync"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 5000
+)
+
+// PageArg selects a page of a list endpoint. PCE pages are 1-based and sized
+// by the "entries" query parameter. The zero value requests no pagination.
+type PageArg struct {
+	Page     int
+	PageSize int
+}
+
+// IsSet reports whether pagination was requested.
+func (p PageArg) IsSet() bool {
+	return p.Page > 0 || p.PageSize > 0
+}
+
+func (p PageArg) normalized() PageArg {
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	if p.PageSize < 1 {
+		p.PageSize = DefaultPageSize
+	}
+	p.PageSize = min(p.PageSize, MaxPageSize)
+	return p
+}
+
+// apply sets the PCE pagination query parameters, if pagination was requested.
+func (p PageArg) apply(query url.Values) {
+	if !p.IsSet() {
+		return
+	}
+	p = p.normalized()
+	query.Set("page", strconv.Itoa(p.Page))
+	query.Set("entries", strconv.Itoa(p.PageSize))
+}
+
+// Page is one page of a list endpoint.
+type Page[T any] struct {
+	Items    []T
+	Page     int
+	PageSize int
+	// Whether a next page may exist. An exactly full last page reports true;
+	// the next page is then empty.
+	HasMore bool
+}
+
+// PageOf wraps items fetched for p into a Page. paginated reports whether
+// the endpoint honoured the pagination parameters; those that don't return
+// every item, which are then paged locally.
+func PageOf[T any](items []T, p PageArg, paginated bool) Page[T] {
+	p = p.normalized()
+	if !paginated {
+		start := min((p.Page-1)*p.PageSize, len(items))
+		end := min(start+p.PageSize, len(items))
+		return Page[T]{Items: items[start:end], Page: p.Page, PageSize: p.PageSize, HasMore: end < len(items)}
+	}
+	return Page[T]{Items: items, Page: p.Page, PageSize: p.PageSize, HasMore: len(items) == p.PageSize}
+}
+
+// unpaginatedEndpoints records the endpoints seen ignoring the pagination
+// parameters.
+var unpaginatedEndpoints sync.Map // path template -> struct{}
+
+// FetchPage fetches page p of endpoint (its path template, e.g.
+// "/v1/organizations/{organization_id}/audit-logs") with fetch and wraps the
+// items into a Page. An endpoint returning more items than requested ignores
+// the pagination parameters: its items are paged locally, and so are those
+// of its later calls, which may return fewer items than a page. Truncated
+// items are returned along with their response_too_large error.
+func FetchPage[T any](endpoint string, p PageArg, fetch func(PageArg) (*[]T, *APIError)) (Page[T], *APIError) {
+	p = p.normalized()
+	items, apiErr := fetchItems(p, fetch)
+
+	paginated := len(items) <= p.PageSize
+	if !paginated {
+		unpaginatedEndpoints.Store(endpoint, struct{}{})
+	} else if _, ok := unpaginatedEndpoints.Load(endpoint); ok {
+		paginated = false
+	}
+	return PageOf(items, p, paginated), apiErr
+}
+
+func fetchItems[T any](p PageArg, fetch func(PageArg) (*[]T, *APIError)) ([]T, *APIError) {
+	items, apiErr := fetch(p)
+	if items == nil {
+		return nil, apiErr
+	}
+	return *items, apiErr
+}
+
+// Paginate iterates over every item of a paginated list endpoint returning a
+// JSON array, fetching pages lazily. The page size is taken from the
+// "entries" query parameter, if set. Iteration stops after the last page, on
+// the first error (yielded with the zero value of T) or when the caller breaks.
+func Paginate[T any](ctx context.Context, c *Client, path string, query url.Values) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		p := PageArg{Page: 1, PageSize: DefaultPageSize}
+		if n, err := strconv.Atoi(query.Get("entries")); err == nil && n > 0 {
+			p.PageSize = n
+		}
+
+		var prev json.RawMessage
+		for {
+			q := maps.Clone(query)
+			if q == nil {
+				q = make(url.Values)
+			}
+			p.apply(q)
+
+			var raw json.RawMessage
+			var items []T
+			apiErr := c.Get(ctx, path, q, &raw)
+			if apiErr == nil {
+				// An endpoint ignoring pagination returns the same page again
+				if bytes.Equal(raw, prev) {
+					return
+				}
+				if err := json.Unmarshal(raw, &items); err != nil {
+					apiErr = WrapAPIError(err, 200, "decoding response")
+				}
+			}
+			if apiErr != nil {
+				var zero T
+				yield(zero, apiErr)
+				return
+			}
+			prev = raw
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			// Stop on the last page, or if the endpoint ignored pagination
+			// and already returned everything
+			if len(items) < p.PageSize || len(items) > p.PageSize {
+				return
+			}
+			p.Page++
+		}
+	}
+}
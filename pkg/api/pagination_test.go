@@ -0,0 +1,58 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFetchPage(t *testing.T) {
+	all := []int{1, 2, 3, 4, 5}
+	paginated := func(p PageArg) (*[]int, *APIError) {
+		start := min((p.Page-1)*p.PageSize, len(all))
+		items := slices.Clone(all[start:min(start+p.PageSize, len(all))])
+		return &items, nil
+	}
+	ignoring := func(p PageArg) (*[]int, *APIError) {
+		items := slices.Clone(all)
+		return &items, nil
+	}
+
+	for _, tc := range []struct {
+		name     string
+		endpoint string
+		fetch    func(PageArg) (*[]int, *APIError)
+		arg      PageArg
+		want     []int
+		hasMore  bool
+	}{
+		{"paginated first", "/v1/paginated", paginated, PageArg{Page: 1, PageSize: 2}, []int{1, 2}, true},
+		{"paginated last", "/v1/paginated", paginated, PageArg{Page: 3, PageSize: 2}, []int{5}, false},
+		{"ignoring first", "/v1/ignoring", ignoring, PageArg{Page: 1, PageSize: 2}, []int{1, 2}, true},
+		{"ignoring last", "/v1/ignoring", ignoring, PageArg{Page: 3, PageSize: 2}, []int{5}, false},
+		// Fewer items than a page, but the endpoint is known to ignore pagination
+		{"ignoring remembered", "/v1/ignoring", ignoring, PageArg{Page: 2, PageSize: 10}, []int{}, false},
+	} {
+		page, apiErr := FetchPage(tc.endpoint, tc.arg, tc.fetch)
+		if apiErr != nil {
+			t.Fatalf("%s: %v", tc.name, apiErr)
+		}
+		if !slices.Equal(page.Items, tc.want) || page.HasMore != tc.hasMore {
+			t.Errorf("%s: got %v (has more: %v), want %v (has more: %v)", tc.name, page.Items, page.HasMore, tc.want, tc.hasMore)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/PextraCloud/pce-mcp/pkg/api/enum"
+)
+
+// ErrPreflightFailed is wrapped by errors returned when a request, checked
+// against the current state of PCE, cannot succeed.
+var ErrPreflightFailed = errors.New("preflight check failed")
+
+// preflightError reports the problems found by a preflight check.
+func preflightError(problems []string) *APIError {
+	err := fmt.Errorf("%w: %s", ErrPreflightFailed, strings.Join(problems, "; "))
+	return WrapAPIError(err, http.StatusBadRequest, err.Error())
+}
+
+// CheckCreateInstance checks arg against the node it targets: the vCPUs and
+// memory of its hardware, and the storage pool, which must be available, able
+// to hold images and have room for the image. The image must exist on the
+// node with the same type. All problems found are reported together in an
+// error wrapping ErrPreflightFailed. Node data is read from PCE, not the
+// cache.
+func CheckCreateInstance(ctx context.Context, c *Client, arg *CreateInstanceArg) *APIError {
+	if apiErr := arg.validate(); apiErr != nil {
+		return apiErr
+	}
+	ctx = WithoutCache(ctx)
+
+	hardware, apiErr := GetNodeHardwareById(ctx, c, &GetNodeHardwareByIdArg{NodeId: arg.NodeId})
+	if apiErr != nil {
+		return apiErr
+	}
+	pools, apiErr := GetNodeStoragePoolsById(ctx, c, &GetNodeStoragePoolsByIdArg{NodeId: arg.NodeId})
+	if apiErr != nil {
+		return apiErr
+	}
+	images, apiErr := ListImagesByNode(ctx, c, &ListImagesByNodeArg{NodeId: arg.NodeId})
+	if apiErr != nil {
+		return apiErr
+	}
+
+	var problems []string
+	if vcpus := arg.Cpu.Vcpus(); hardware.Vcpus > 0 && vcpus > hardware.Vcpus {
+		problems = append(problems, fmt.Sprintf("%d vCPUs requested, node %s has %d", vcpus, arg.NodeId, hardware.Vcpus))
+	}
+	if memory := hardware.MemoryMB(); memory > 0 && arg.MemoryMB > memory {
+		problems = append(problems, fmt.Sprintf("%d MB of memory requested, node %s has %d MB", arg.MemoryMB, arg.NodeId, memory))
+	}
+
+	var image *ImageList
+	for i := range *images {
+		if (*images)[i].Name == arg.Image {
+			image = &(*images)[i]
+			break
+		}
+	}
+	switch {
+	case image == nil:
+		problems = append(problems, fmt.Sprintf("image %q not found on node %s", arg.Image, arg.NodeId))
+	case image.Type != arg.Type:
+		problems = append(problems, fmt.Sprintf("image %q has type %s, not %s", arg.Image, image.Type, arg.Type))
+	}
+
+	var pool *StoragePoolDetail
+	for i := range *pools {
+		if (*pools)[i].Id == arg.StoragePoolId {
+			pool = &(*pools)[i]
+			break
+		}
+	}
+	switch {
+	case pool == nil:
+		problems = append(problems, fmt.Sprintf("storage pool %s not found on node %s", arg.StoragePoolId, arg.NodeId))
+	case !pool.Initialized || !pool.Available:
+		problems = append(problems, fmt.Sprintf("storage pool %s is not available", pool.Id))
+	case !pool.CanHoldImages:
+		problems = append(problems, fmt.Sprintf("storage pool %s cannot hold images", pool.Id))
+	case image != nil && float64(image.SizeMB)/1024 > pool.Usage.AvailableGB:
+		problems = append(problems, fmt.Sprintf("image %q needs %.1f GB, storage pool %s has %.1f GB free", image.Name, float64(image.SizeMB)/1024, pool.Id, pool.Usage.AvailableGB))
+	}
+
+	if len(problems) > 0 {
+		return preflightError(problems)
+	}
+	return nil
+}
+
+// CheckDeleteInstance checks that the instance exists and, unless arg.Force
+// is set, is stopped. The instance is returned for reference; it is read
+// from PCE, not the cache.
+func CheckDeleteInstance(ctx context.Context, c *Client, arg *DeleteInstanceArg) (*InstanceDetail, *APIError) {
+	if arg == nil || arg.NodeId == "" || arg.InstanceId == "" {
+		return nil, NewAPIError(400, "node_id and instance_id are required")
+	}
+	ctx = WithoutCache(ctx)
+
+	instance, apiErr := GetInstanceById(ctx, c, &GetInstanceByIdArg{NodeId: arg.NodeId, InstanceId: arg.InstanceId})
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if !arg.Force && !instance.Status.State.IsStopped() {
+		return instance, preflightError([]string{fmt.Sprintf("instance %s is %s, not stopped", arg.InstanceId, instance.Status.State)})
+	}
+	return instance, nil
+}
+
+// CheckCreateInstanceSnapshot checks that the instance exists and, if the
+// memory state is to be saved, that it is a running QEMU instance.
+func CheckCreateInstanceSnapshot(ctx context.Context, c *Client, arg *CreateInstanceSnapshotArg) *APIError {
+	if arg == nil || arg.NodeId == "" || arg.InstanceId == "" || arg.Name == "" {
+		return NewAPIError(400, "node_id, instance_id and name are required")
+	}
+
+	instance, apiErr := GetInstanceById(ctx, c, &GetInstanceByIdArg{NodeId: arg.NodeId, InstanceId: arg.InstanceId})
+	if apiErr != nil {
+		return apiErr
+	}
+	if !arg.IncludeMemory {
+		return nil
+	}
+
+	var problems []string
+	if instanceType := enum.InstanceTypeEnum(instance.Type); instanceType != enum.InstanceTypeEnumQEMU {
+		problems = append(problems, fmt.Sprintf("memory state can only be saved for qemu instances, instance %s is %s", arg.InstanceId, instanceType))
+	}
+	if instance.Status.State != enum.InstanceStateRunning {
+		problems = append(problems, fmt.Sprintf("memory state can only be saved for running instances, instance %s is %s", arg.InstanceId, instance.Status.State))
+	}
+	if len(problems) > 0 {
+		return preflightError(problems)
+	}
+	return nil
+}
+
+// MigrationCheck is the outcome of a successful CheckMigrateInstance.
+type MigrationCheck struct {
+	Instance *InstanceDetail `json:"-"`
+	// Free memory of the target node after the migration, in MB (0 if unknown)
+	TargetFreeMemoryMB int `json:"target_free_memory_mb,omitempty"`
+	// CPU flags of the source node missing on the target node
+	MissingCpuFlags []string `json:"missing_cpu_flags,omitempty"`
+	// Problems that may make a live migration fail or crash the instance,
+	// accepted with IgnoreCpuWarnings
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// CheckMigrateInstance checks arg against both nodes: the target must be
+// alive, in the same cluster and have enough free memory (its installed
+// memory minus that of its instances), and the target storage pool, if any,
+// must be available with room for the instance volumes. A live migration
+// needs a running instance, an offline one a stopped instance. For live
+// migrations, CPU flags of the source node missing on the target and
+// different CPU manufacturers are warnings: they fail the check too, unless
+// arg.IgnoreCpuWarnings is set, in which case they are returned in the
+// MigrationCheck. Both nodes are read from PCE, not the cache.
+func CheckMigrateInstance(ctx context.Context, c *Client, arg *MigrateInstanceArg) (*MigrationCheck, *APIError) {
+	if arg == nil || arg.NodeId == "" || arg.InstanceId == "" || arg.TargetNodeId == "" {
+		return nil, NewAPIError(400, "node_id, instance_id and target_node_id are required")
+	}
+	if arg.NodeId == arg.TargetNodeId {
+		return nil, NewAPIError(400, "target_node_id must differ from node_id")
+	}
+	ctx = WithoutCache(ctx)
+
+	instance, apiErr := GetInstanceById(ctx, c, &GetInstanceByIdArg{NodeId: arg.NodeId, InstanceId: arg.InstanceId})
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	source, apiErr := GetNodeById(ctx, c, &GetNodeByIdArg{NodeId: arg.NodeId})
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target, apiErr := GetNodeById(ctx, c, &GetNodeByIdArg{NodeId: arg.TargetNodeId})
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	sourceHardware, apiErr := GetNodeHardwareById(ctx, c, &GetNodeHardwareByIdArg{NodeId: arg.NodeId})
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	targetHardware, apiErr := GetNodeHardwareById(ctx, c, &GetNodeHardwareByIdArg{NodeId: arg.TargetNodeId})
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	check := &MigrationCheck{Instance: instance}
+	var problems []string
+
+	// Instance state
+	switch {
+	case arg.Live && instance.Status.State != enum.InstanceStateRunning:
+		problems = append(problems, fmt.Sprintf("live migration needs a running instance, instance %s is %s", arg.InstanceId, instance.Status.State))
+	case !arg.Live && !instance.Status.State.IsStopped():
+		problems = append(problems, fmt.Sprintf("offline migration needs a stopped instance, instance %s is %s", arg.InstanceId, instance.Status.State))
+	}
+
+	// Target node
+	if !target.Node.Alive {
+		problems = append(problems, fmt.Sprintf("target node %s is not alive", arg.TargetNodeId))
+	}
+	if target.Node.ClusterId != source.Node.ClusterId {
+		problems = append(problems, fmt.Sprintf("target node %s is in cluster %s, not %s", arg.TargetNodeId, target.Node.ClusterId, source.Node.ClusterId))
+	}
+	if total := targetHardware.MemoryMB(); total > 0 {
+		free := total
+		for _, i := range target.Instances {
+			free -= i.Memory
+		}
+		if instance.Memory > free {
+			problems = append(problems, fmt.Sprintf("instance %s needs %d MB of memory, target node %s has %d MB free", arg.InstanceId, instance.Memory, arg.TargetNodeId, max(0, free)))
+		} else {
+			check.TargetFreeMemoryMB = free - instance.Memory
+		}
+	}
+
+	// Target storage pool
+	if arg.TargetStoragePoolId != "" {
+		pools, apiErr := GetNodeStoragePoolsById(ctx, c, &GetNodeStoragePoolsByIdArg{NodeId: arg.TargetNodeId})
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		var pool *StoragePoolDetail
+		for i := range *pools {
+			if (*pools)[i].Id == arg.TargetStoragePoolId {
+				pool = &(*pools)[i]
+				break
+			}
+		}
+		var sizeGB float64
+		for _, disk := range instance.Disks {
+			sizeGB += disk.SizeGB
+		}
+		switch {
+		case pool == nil:
+			problems = append(problems, fmt.Sprintf("storage pool %s not found on target node %s", arg.TargetStoragePoolId, arg.TargetNodeId))
+		case !pool.Initialized || !pool.Available:
+			problems = append(problems, fmt.Sprintf("storage pool %s is not available", pool.Id))
+		case sizeGB > pool.Usage.AvailableGB:
+			problems = append(problems, fmt.Sprintf("instance volumes need %.1f GB, storage pool %s has %.1f GB free", sizeGB, pool.Id, pool.Usage.AvailableGB))
+		}
+	}
+
+	// CPU compatibility, which only matters while the instance runs
+	if arg.Live {
+		targetFlags := make(map[string]bool, len(targetHardware.CPU.Flags))
+		for _, flag := range targetHardware.CPU.Flags {
+			targetFlags[flag] = true
+		}
+		for _, flag := range sourceHardware.CPU.Flags {
+			if !targetFlags[flag] {
+				check.MissingCpuFlags = append(check.MissingCpuFlags, flag)
+			}
+		}
+		if len(check.MissingCpuFlags) > 0 {
+			check.Warnings = append(check.Warnings, fmt.Sprintf("target node %s lacks CPU flags of node %s (%s); the instance may crash if it uses them", arg.TargetNodeId, arg.NodeId, strings.Join(check.MissingCpuFlags, ", ")))
+		}
+		if s, t := sourceHardware.CPU.Manufacturer, targetHardware.CPU.Manufacturer; s != "" && t != "" && s != t {
+			check.Warnings = append(check.Warnings, fmt.Sprintf("CPU manufacturers differ (%s on node %s, %s on node %s); live migration between them is unsupported", s, arg.NodeId, t, arg.TargetNodeId))
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, preflightError(append(problems, check.Warnings...))
+	}
+	if len(check.Warnings) > 0 && !arg.IgnoreCpuWarnings {
+		return nil, preflightError(append(check.Warnings, "set ignore_cpu_warnings to migrate anyway"))
+	}
+	return check, nil
+}
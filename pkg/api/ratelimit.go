@@ -0,0 +1,120 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is wrapped by errors returned when a client-side rate limit
+// is exhausted. Such requests are never sent, and never retried automatically.
+var ErrRateLimited = errors.New("rate limited")
+
+// RateLimiter is a token bucket limiter. It never blocks: requests that find
+// the bucket empty fail immediately with the time until a token is available.
+type RateLimiter struct {
+	rate  float64 // tokens per second
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a limiter allowing ratePerSecond requests on average,
+// with bursts of up to burst requests (default: one second worth of requests).
+// Returns nil (no limit) if ratePerSecond <= 0.
+func NewRateLimiter(ratePerSecond float64, burst int) *RateLimiter {
+	if ratePerSecond <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = max(1, int(math.Ceil(ratePerSecond)))
+	}
+	return &RateLimiter{
+		rate:   ratePerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// take consumes a token, or returns how long until one is available.
+func (l *RateLimiter) take(now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	if l.tokens >= 1 {
+		l.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
+
+// refund returns a token taken by a request that was not sent after all.
+func (l *RateLimiter) refund() {
+	l.mu.Lock()
+	l.tokens = math.Min(l.burst, l.tokens+1)
+	l.mu.Unlock()
+}
+
+// RateLimits groups the limiters applied to each request attempt. Share the
+// Global, Read and Mutation limiters between clients to bound the total load
+// on PCE; give each client (e.g., each session) its own Client limiter.
+type RateLimits struct {
+	Global   *RateLimiter
+	Client   *RateLimiter
+	Read     *RateLimiter // GET, HEAD and OPTIONS requests
+	Mutation *RateLimiter // all other requests
+}
+
+// allow takes a token from every applicable limiter, or none at all.
+func (rl RateLimits) allow(method string) *APIError {
+	class := rl.Mutation
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		class = rl.Read
+	}
+
+	now := time.Now()
+	var taken []*RateLimiter
+	for _, l := range []*RateLimiter{rl.Global, rl.Client, class} {
+		if l == nil {
+			continue
+		}
+		ok, wait := l.take(now)
+		if !ok {
+			for _, t := range taken {
+				t.refund()
+			}
+			return &APIError{
+				Err:        fmt.Errorf("%w, retry after %.1fs", ErrRateLimited, wait.Seconds()),
+				Status:     http.StatusTooManyRequests,
+				Message:    "rate limited",
+				RetryAfter: wait,
+			}
+		}
+		taken = append(taken, l)
+	}
+	return nil
+}
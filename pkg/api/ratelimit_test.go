@@ -0,0 +1,102 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterTake(t *testing.T) {
+	l := NewRateLimiter(2, 2)
+	now := l.last
+	for i := range 2 {
+		if ok, _ := l.take(now); !ok {
+			t.Fatalf("take %d within the burst failed", i)
+		}
+	}
+	ok, wait := l.take(now)
+	if ok || wait != 500*time.Millisecond {
+		t.Errorf("got ok=%v wait=%v with an empty bucket, want false and 500ms", ok, wait)
+	}
+	if ok, _ := l.take(now.Add(500 * time.Millisecond)); !ok {
+		t.Error("take after refilling one token failed")
+	}
+	// Refilling never exceeds the burst
+	if ok, _ := l.take(now.Add(time.Hour)); !ok {
+		t.Fatal("take after a long idle period failed")
+	}
+	if l.tokens != 1 {
+		t.Errorf("got %v tokens after a long idle period, want burst-1 = 1", l.tokens)
+	}
+}
+
+func TestNewRateLimiterDefaults(t *testing.T) {
+	if l := NewRateLimiter(0, 5); l != nil {
+		t.Error("NewRateLimiter(0, 5) is not nil")
+	}
+	if l := NewRateLimiter(2.5, 0); l.burst != 3 {
+		t.Errorf("got burst %v for 2.5 req/s, want 3", l.burst)
+	}
+}
+
+func TestRateLimitsRefundOnReject(t *testing.T) {
+	global := NewRateLimiter(1, 5)
+	client := NewRateLimiter(1, 5)
+	mutation := NewRateLimiter(1, 1)
+	rl := RateLimits{Global: global, Client: client, Mutation: mutation}
+
+	if apiErr := rl.allow(http.MethodPost); apiErr != nil {
+		t.Fatalf("first POST: %v", apiErr)
+	}
+	apiErr := rl.allow(http.MethodPost)
+	if apiErr == nil || !errors.Is(apiErr, ErrRateLimited) || apiErr.RetryAfter <= 0 {
+		t.Fatalf("second POST got %v, want ErrRateLimited with a RetryAfter", apiErr)
+	}
+	// The rejected POST must not use up the global and client tokens
+	for _, l := range []*RateLimiter{global, client} {
+		if l.tokens < 3.9 {
+			t.Errorf("got %v tokens after a rejected request, want about 4", l.tokens)
+		}
+	}
+	// Reads are not limited by the mutation limiter
+	if apiErr := rl.allow(http.MethodGet); apiErr != nil {
+		t.Errorf("GET: %v", apiErr)
+	}
+}
+
+func TestRateLimitedRequestNotSent(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte("{}"))
+	}), WithRateLimits(RateLimits{Client: NewRateLimiter(0.001, 1)}))
+
+	if apiErr := c.Get(context.Background(), "/v1/test", nil, nil); apiErr != nil {
+		t.Fatalf("first Get: %v", apiErr)
+	}
+	apiErr := c.Get(context.Background(), "/v1/test", nil, nil)
+	if !errors.Is(apiErr, ErrRateLimited) {
+		t.Fatalf("second Get got %v, want ErrRateLimited", apiErr)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("got %d calls, want 1", calls.Load())
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how Client.Do retries failed requests. Only transport
+// errors, 429 and 5xx responses are retried.
+type RetryPolicy struct {
+	// Total number of attempts, including the first one. Values <= 1 disable retries.
+	MaxAttempts int
+	// Backoff before the first retry; doubled on each subsequent retry.
+	InitialBackoff time.Duration
+	// Upper bound for the computed backoff (does not cap Retry-After).
+	MaxBackoff time.Duration
+	// Retry non-idempotent methods (e.g., POST) for every request. Prefer
+	// opting in per request with WithRetryNonIdempotent.
+	RetryNonIdempotent bool
+}
+
+const (
+	DefaultRetryMaxAttempts    = 3
+	DefaultRetryInitialBackoff = 200 * time.Millisecond
+	DefaultRetryMaxBackoff     = 5 * time.Second
+)
+
+// DefaultRetryPolicy returns the policy used by NewClient.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    DefaultRetryMaxAttempts,
+		InitialBackoff: DefaultRetryInitialBackoff,
+		MaxBackoff:     DefaultRetryMaxBackoff,
+	}
+}
+
+type retryNonIdempotentKey struct{}
+
+// WithRetryNonIdempotent marks requests made with the returned context as safe
+// to replay even if their method is not idempotent.
+func WithRetryNonIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryNonIdempotentKey{}, true)
+}
+
+// canRetry reports whether req may be replayed under the policy.
+func (p RetryPolicy) canRetry(req *http.Request) bool {
+	if p.MaxAttempts <= 1 || !replayableBody(req) {
+		return false
+	}
+	if idempotent(req) || p.RetryNonIdempotent {
+		return true
+	}
+	optIn, _ := req.Context().Value(retryNonIdempotentKey{}).(bool)
+	return optIn
+}
+
+// replayableBody reports whether the body of req can be sent again.
+func replayableBody(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+type notIdempotentKey struct{}
+
+// withoutReplay marks requests made with the returned context as not
+// idempotent whatever their method, e.g. a DELETE starting a task that a
+// replay would find gone, so that they are neither retried nor replayed
+// after a 401.
+func withoutReplay(ctx context.Context) context.Context {
+	return context.WithValue(ctx, notIdempotentKey{}, true)
+}
+
+// idempotent reports whether the method of req is idempotent.
+func idempotent(req *http.Request) bool {
+	if notIdempotent, _ := req.Context().Value(notIdempotentKey{}).(bool); notIdempotent {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// backoff returns the delay before retry number n (1-based), with full jitter.
+func (p RetryPolicy) backoff(n int) time.Duration {
+	d := p.InitialBackoff
+	if d <= 0 {
+		d = DefaultRetryInitialBackoff
+	}
+	for i := 1; i < n && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// retryableStatus reports whether a response status is worth retrying.
+func retryableStatus(status int) bool {
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	return status >= 500 && status != http.StatusNotImplemented && status != http.StatusHTTPVersionNotSupported
+}
+
+// retryAfter parses a Retry-After header (delay-seconds or HTTP-date).
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client of a test server running handler.
+func newTestClient(t *testing.T, handler http.Handler, opts ...Option) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c, err := New(srv.URL, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// fastRetries retries quickly, to keep tests short.
+var fastRetries = WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+func TestRetryHonoursRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"message":"unavailable"}`)
+			return
+		}
+		fmt.Fprint(w, `{"ok":true}`)
+	}), fastRetries)
+
+	start := time.Now()
+	var out struct {
+		Ok bool `json:"ok"`
+	}
+	if apiErr := c.Get(context.Background(), "/v1/test", nil, &out); apiErr != nil {
+		t.Fatalf("Get: %v", apiErr)
+	}
+	if !out.Ok || calls.Load() != 2 {
+		t.Errorf("got ok=%v after %d calls, want ok=true after 2", out.Ok, calls.Load())
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, want at least the Retry-After of 1s", elapsed)
+	}
+}
+
+func TestRetryRecordsAttempts(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"message":"unavailable"}`)
+	}), fastRetries)
+
+	apiErr := c.Get(context.Background(), "/v1/test", nil, nil)
+	if apiErr == nil {
+		t.Fatal("Get succeeded, want an error")
+	}
+	if apiErr.Status != http.StatusServiceUnavailable || apiErr.Attempts != 3 || calls.Load() != 3 {
+		t.Errorf("got status %d after %d attempts (%d calls), want 503 after 3", apiErr.Status, apiErr.Attempts, calls.Load())
+	}
+}
+
+func TestRetrySkipsNonIdempotent(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}), fastRetries)
+
+	apiErr := c.Post(context.Background(), "/v1/test", nil, nil, nil)
+	if apiErr == nil || apiErr.Attempts != 1 || calls.Load() != 1 {
+		t.Fatalf("got %v after %d calls, want a single attempt", apiErr, calls.Load())
+	}
+
+	calls.Store(0)
+	apiErr = c.Post(WithRetryNonIdempotent(context.Background()), "/v1/test", nil, nil, nil)
+	if apiErr == nil || apiErr.Attempts != 3 || calls.Load() != 3 {
+		t.Errorf("got %v after %d calls, want 3 attempts with WithRetryNonIdempotent", apiErr, calls.Load())
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for _, tc := range []struct {
+		n        int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+	} {
+		for range 100 {
+			if d := p.backoff(tc.n); d < tc.min || d > tc.max {
+				t.Fatalf("backoff(%d) = %v, want between %v and %v", tc.n, d, tc.min, tc.max)
+			}
+		}
+	}
+}
+
+func TestRetrySkipsRequestsWithoutReplay(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}), fastRetries)
+
+	apiErr := c.Delete(withoutReplay(context.Background()), "/v1/test", nil, nil)
+	if apiErr == nil || apiErr.Attempts != 1 || calls.Load() != 1 {
+		t.Errorf("got %v after %d calls, want a single attempt", apiErr, calls.Load())
+	}
+}
@@ -0,0 +1,349 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// SchemaRecorder records the differences ("drift") between PCE responses and
+// the Go types they are decoded into: fields PCE sends that the type lacks,
+// fields of the type PCE doesn't send, and fields with a different JSON type.
+// Decoding itself stays lenient. It is safe for concurrent use.
+type SchemaRecorder struct {
+	logger *slog.Logger
+
+	mu        sync.Mutex
+	endpoints map[string]*schemaEndpoint
+}
+
+type schemaEndpoint struct {
+	typ        string
+	unknown    map[string]bool
+	missing    map[string]bool
+	mismatched map[string]string
+}
+
+// EndpointDrift is the drift recorded for one endpoint.
+type EndpointDrift struct {
+	// Path template, e.g. "/v1/nodes/{id}/hardware"
+	Endpoint string `json:"endpoint"`
+	// Go type the responses are decoded into
+	Type string `json:"type"`
+	// Fields present in responses but not in Type
+	Unknown []string `json:"unknown_fields,omitempty"`
+	// Fields of Type absent from responses
+	Missing []string `json:"missing_fields,omitempty"`
+	// Fields whose JSON type doesn't match Type, e.g. "vcpus: expected number, got string"
+	Mismatched []string `json:"mismatched_fields,omitempty"`
+}
+
+// HasDrift reports whether any drift was recorded.
+func (d EndpointDrift) HasDrift() bool {
+	return len(d.Unknown) > 0 || len(d.Missing) > 0 || len(d.Mismatched) > 0
+}
+
+// NewSchemaRecorder returns a recorder that logs each newly found difference
+// with logger at warn level. A nil logger records silently.
+func NewSchemaRecorder(logger *slog.Logger) *SchemaRecorder {
+	return &SchemaRecorder{
+		logger:    logger,
+		endpoints: make(map[string]*schemaEndpoint),
+	}
+}
+
+// Report returns the drift of every endpoint checked so far, sorted by endpoint.
+func (r *SchemaRecorder) Report() []EndpointDrift {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := make([]EndpointDrift, 0, len(r.endpoints))
+	for _, name := range slices.Sorted(maps.Keys(r.endpoints)) {
+		e := r.endpoints[name]
+		d := EndpointDrift{
+			Endpoint: name,
+			Type:     e.typ,
+			Unknown:  slices.Sorted(maps.Keys(e.unknown)),
+			Missing:  slices.Sorted(maps.Keys(e.missing)),
+		}
+		for _, field := range slices.Sorted(maps.Keys(e.mismatched)) {
+			d.Mismatched = append(d.Mismatched, field+": "+e.mismatched[field])
+		}
+		report = append(report, d)
+	}
+	return report
+}
+
+// check compares the JSON response data for path with the type of out.
+func (r *SchemaRecorder) check(path string, data []byte, out any) {
+	if r == nil || out == nil {
+		return
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return
+	}
+	t := reflect.TypeOf(out)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	d := &schemaDiff{
+		unknown:    make(map[string]bool),
+		missing:    make(map[string]bool),
+		mismatched: make(map[string]string),
+	}
+	d.compare("", v, t)
+
+	endpoint := endpointTemplate(path)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, ok := r.endpoints[endpoint]
+	if !ok {
+		e = &schemaEndpoint{
+			typ:        t.String(),
+			unknown:    make(map[string]bool),
+			missing:    make(map[string]bool),
+			mismatched: make(map[string]string),
+		}
+		r.endpoints[endpoint] = e
+	}
+	for field := range d.unknown {
+		if !e.unknown[field] {
+			e.unknown[field] = true
+			r.warn(endpoint, "unknown field", field, "")
+		}
+	}
+	for field := range d.missing {
+		if !e.missing[field] {
+			e.missing[field] = true
+			r.warn(endpoint, "missing field", field, "")
+		}
+	}
+	for field, detail := range d.mismatched {
+		if _, ok := e.mismatched[field]; !ok {
+			e.mismatched[field] = detail
+			r.warn(endpoint, "mismatched field", field, detail)
+		}
+	}
+}
+
+func (r *SchemaRecorder) warn(endpoint, msg, field, detail string) {
+	if r.logger == nil {
+		return
+	}
+	attrs := []any{"endpoint", endpoint, "field", field}
+	if detail != "" {
+		attrs = append(attrs, "detail", detail)
+	}
+	r.logger.Warn("PCE API schema drift: "+msg, attrs...)
+}
+
+// endpointTemplate replaces the resource id of path, e.g.
+// "/v1/nodes/node-1/hardware" becomes "/v1/nodes/{id}/hardware".
+func endpointTemplate(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 2 {
+		segments[2] = "{id}"
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// schemaDiff collects the differences found in one response.
+type schemaDiff struct {
+	unknown    map[string]bool
+	missing    map[string]bool
+	mismatched map[string]string
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// compare walks the generic JSON value v alongside t. Map keys and slice
+// elements are aggregated as "*" and "[]" in field paths.
+func (d *schemaDiff) compare(path string, v any, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if v == nil {
+		return // null decodes into anything
+	}
+	// Custom decoding can't be checked
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := v.(map[string]any)
+		if !ok {
+			d.mismatch(path, "object", v)
+			return
+		}
+		fields := jsonFields(t)
+		seen := make(map[string]bool, len(fields))
+		for key, val := range m {
+			f, ok := lookupField(fields, key)
+			if !ok {
+				d.unknown[joinField(path, key)] = true
+				continue
+			}
+			seen[f.name] = true
+			d.compare(joinField(path, f.name), val, f.typ)
+		}
+		for _, f := range fields {
+			if !seen[f.name] && !f.omitempty {
+				d.missing[joinField(path, f.name)] = true
+			}
+		}
+	case reflect.Map:
+		m, ok := v.(map[string]any)
+		if !ok {
+			d.mismatch(path, "object", v)
+			return
+		}
+		for _, val := range m {
+			d.compare(joinField(path, "*"), val, t.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			if _, ok := v.(string); !ok {
+				d.mismatch(path, "string", v)
+			}
+			return
+		}
+		items, ok := v.([]any)
+		if !ok {
+			d.mismatch(path, "array", v)
+			return
+		}
+		for _, item := range items {
+			d.compare(path+"[]", item, t.Elem())
+		}
+	case reflect.String:
+		if _, ok := v.(string); !ok {
+			d.mismatch(path, "string", v)
+		}
+	case reflect.Bool:
+		if _, ok := v.(bool); !ok {
+			d.mismatch(path, "boolean", v)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if _, ok := v.(float64); !ok {
+			d.mismatch(path, "number", v)
+		}
+	}
+}
+
+func (d *schemaDiff) mismatch(path, expected string, got any) {
+	if path == "" {
+		path = "(root)"
+	}
+	d.mismatched[path] = fmt.Sprintf("expected %s, got %s", expected, jsonKind(got))
+}
+
+func jsonKind(v any) string {
+	switch v.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return "null"
+}
+
+func joinField(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+type jsonField struct {
+	name      string
+	typ       reflect.Type
+	omitempty bool
+}
+
+// jsonFields returns the fields encoding/json decodes into t, including those
+// promoted from embedded structs.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, jsonFields(ft)...)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, jsonField{
+			name:      name,
+			typ:       sf.Type,
+			omitempty: strings.Contains(opts, "omitempty") || strings.Contains(opts, "omitzero"),
+		})
+	}
+	return fields
+}
+
+// lookupField finds the field key decodes into; like encoding/json, an exact
+// match is preferred over a case-insensitive one.
+func lookupField(fields []jsonField, key string) (jsonField, bool) {
+	for _, f := range fields {
+		if f.name == key {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f, true
+		}
+	}
+	return jsonField{}, false
+}
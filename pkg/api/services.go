@@ -0,0 +1,264 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/PextraCloud/pce-mcp/pkg/api/enum"
+)
+
+// Resource-oriented services, available as fields of Client, e.g.:
+//
+//	node, err := client.Nodes.Get(ctx, "node-xxx")
+//	instances, err := client.Instances.List(ctx, api.InCluster("cls-xxx"))
+//
+// Each service is an interface so it can be replaced with a test double. The
+// services are thin wrappers around the package-level functions.
+
+type OrganizationsService interface {
+	List(ctx context.Context) ([]OrganizationDetail, *APIError)
+	Get(ctx context.Context, organizationId string) (*OrganizationDetail, *APIError)
+	Create(ctx context.Context, name, description string) (*CreateOrganizationResponse, *APIError)
+	Delete(ctx context.Context, organizationId string) *APIError
+	AuditLogs(ctx context.Context, organizationId string, page PageArg) ([]AuditLogEntry, *APIError)
+}
+
+type UsersService interface {
+	List(ctx context.Context, organizationId string) ([]UserList, *APIError)
+	Delete(ctx context.Context, userId string) *APIError
+	InvalidateSessions(ctx context.Context, userId string, invalidateCurrent bool) *APIError
+}
+
+type ClustersService interface {
+	Hardware(ctx context.Context, clusterId string) (*GetClusterHardwareByIdResponse, *APIError)
+	Licensing(ctx context.Context, clusterId string) (*GetClusterLicensingByIdResponse, *APIError)
+}
+
+type NodesService interface {
+	Get(ctx context.Context, nodeId string) (*GetNodeByIdResponse, *APIError)
+	Hardware(ctx context.Context, nodeId string) (*GetNodeHardwareByIdResponse, *APIError)
+	License(ctx context.Context, nodeId string) (*GetNodeLicenseByIdResponse, *APIError)
+	StoragePools(ctx context.Context, nodeId string) ([]StoragePoolDetail, *APIError)
+	PciDevices(ctx context.Context, nodeId string) ([]NodePciDevice, *APIError)
+	Images(ctx context.Context, nodeId string) ([]ImageList, *APIError)
+	Healthcheck(ctx context.Context) (*RunHealthcheckResponse, *APIError)
+}
+
+type InstancesService interface {
+	List(ctx context.Context, scope InstanceScope) ([]InstanceList, *APIError)
+	Get(ctx context.Context, nodeId, instanceId string) (*InstanceDetail, *APIError)
+	Create(ctx context.Context, arg *CreateInstanceArg) (*CreateInstanceResponse, *APIError)
+	Delete(ctx context.Context, arg *DeleteInstanceArg) (*DeleteInstanceResponse, *APIError)
+	Migrate(ctx context.Context, arg *MigrateInstanceArg) (*MigrateInstanceResponse, *APIError)
+	Power(ctx context.Context, nodeId, instanceId string, action enum.InstancePowerAction) (*PowerInstanceResponse, *APIError)
+}
+
+type SnapshotsService interface {
+	List(ctx context.Context, nodeId, instanceId string) ([]SnapshotDetail, *APIError)
+	Create(ctx context.Context, arg *CreateInstanceSnapshotArg) (*CreateInstanceSnapshotResponse, *APIError)
+	Revert(ctx context.Context, nodeId, instanceId, snapshotId string) (*RevertInstanceSnapshotResponse, *APIError)
+	Delete(ctx context.Context, nodeId, instanceId, snapshotId string) (*DeleteInstanceSnapshotResponse, *APIError)
+}
+
+type TasksService interface {
+	Get(ctx context.Context, taskId string) (*TaskDetail, *APIError)
+	List(ctx context.Context, nodeId string, limit int) ([]TaskDetail, *APIError)
+	Wait(ctx context.Context, taskId string, pollInterval time.Duration) (*TaskDetail, *APIError)
+}
+
+// InstanceScope selects where instances are listed; see InNode and InCluster.
+type InstanceScope func(*GetInstancesByIdArg)
+
+// InNode lists the instances of a single node.
+func InNode(nodeId string) InstanceScope {
+	return func(a *GetInstancesByIdArg) { a.NodeId = nodeId }
+}
+
+// InCluster lists the instances of all nodes in a cluster.
+func InCluster(clusterId string) InstanceScope {
+	return func(a *GetInstancesByIdArg) { a.ClusterId = clusterId }
+}
+
+// initServices points the service fields of c at the default implementations.
+func (c *Client) initServices() {
+	c.Organizations = organizationsService{c}
+	c.Users = usersService{c}
+	c.Clusters = clustersService{c}
+	c.Nodes = nodesService{c}
+	c.Instances = instancesService{c}
+	c.Snapshots = snapshotsService{c}
+	c.Tasks = tasksService{c}
+}
+
+// deref returns the value of a list response, or nil.
+func deref[T any](p *[]T) []T {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+type organizationsService struct{ c *Client }
+
+func (s organizationsService) List(ctx context.Context) ([]OrganizationDetail, *APIError) {
+	resp, apiErr := ListOrganizations(ctx, s.c, &ListOrganizationsArg{})
+	return deref(resp), apiErr
+}
+
+func (s organizationsService) Get(ctx context.Context, organizationId string) (*OrganizationDetail, *APIError) {
+	return GetOrganizationById(ctx, s.c, &GetOrganizationByIdArg{OrganizationId: organizationId})
+}
+
+func (s organizationsService) Create(ctx context.Context, name, description string) (*CreateOrganizationResponse, *APIError) {
+	return CreateOrganization(ctx, s.c, &CreateOrganizationArg{Name: name, Description: description})
+}
+
+func (s organizationsService) Delete(ctx context.Context, organizationId string) *APIError {
+	_, apiErr := DeleteOrganizationById(ctx, s.c, &DeleteOrganizationByIdArg{OrganizationId: organizationId})
+	return apiErr
+}
+
+func (s organizationsService) AuditLogs(ctx context.Context, organizationId string, page PageArg) ([]AuditLogEntry, *APIError) {
+	resp, apiErr := ListOrganizationAuditLogsById(ctx, s.c, &ListOrganizationAuditLogsByIdArg{OrganizationId: organizationId, PageArg: page})
+	return deref(resp), apiErr
+}
+
+type usersService struct{ c *Client }
+
+func (s usersService) List(ctx context.Context, organizationId string) ([]UserList, *APIError) {
+	resp, apiErr := ListUsersInOrganizationById(ctx, s.c, &ListUsersInOrganizationByIdArg{OrganizationId: organizationId})
+	return deref(resp), apiErr
+}
+
+func (s usersService) Delete(ctx context.Context, userId string) *APIError {
+	_, apiErr := DeleteUserById(ctx, s.c, &DeleteUserByIdArg{UserId: userId})
+	return apiErr
+}
+
+func (s usersService) InvalidateSessions(ctx context.Context, userId string, invalidateCurrent bool) *APIError {
+	_, apiErr := InvalidateUserSessionsById(ctx, s.c, &InvalidateUserSessionsByIdArg{UserId: userId, InvalidateCurrent: invalidateCurrent})
+	return apiErr
+}
+
+type clustersService struct{ c *Client }
+
+func (s clustersService) Hardware(ctx context.Context, clusterId string) (*GetClusterHardwareByIdResponse, *APIError) {
+	return GetClusterHardwareById(ctx, s.c, &GetClusterHardwareByIdArg{ClusterId: clusterId})
+}
+
+func (s clustersService) Licensing(ctx context.Context, clusterId string) (*GetClusterLicensingByIdResponse, *APIError) {
+	return GetClusterLicensingById(ctx, s.c, &GetClusterLicensingByIdArg{ClusterId: clusterId})
+}
+
+type nodesService struct{ c *Client }
+
+func (s nodesService) Get(ctx context.Context, nodeId string) (*GetNodeByIdResponse, *APIError) {
+	return GetNodeById(ctx, s.c, &GetNodeByIdArg{NodeId: nodeId})
+}
+
+func (s nodesService) Hardware(ctx context.Context, nodeId string) (*GetNodeHardwareByIdResponse, *APIError) {
+	return GetNodeHardwareById(ctx, s.c, &GetNodeHardwareByIdArg{NodeId: nodeId})
+}
+
+func (s nodesService) License(ctx context.Context, nodeId string) (*GetNodeLicenseByIdResponse, *APIError) {
+	return GetNodeLicenseById(ctx, s.c, &GetNodeLicenseByIdArg{NodeId: nodeId})
+}
+
+func (s nodesService) StoragePools(ctx context.Context, nodeId string) ([]StoragePoolDetail, *APIError) {
+	resp, apiErr := GetNodeStoragePoolsById(ctx, s.c, &GetNodeStoragePoolsByIdArg{NodeId: nodeId})
+	return deref(resp), apiErr
+}
+
+func (s nodesService) PciDevices(ctx context.Context, nodeId string) ([]NodePciDevice, *APIError) {
+	resp, apiErr := GetNodePciDevicesById(ctx, s.c, &GetNodePciDevicesByIdArg{NodeId: nodeId})
+	return deref(resp), apiErr
+}
+
+func (s nodesService) Images(ctx context.Context, nodeId string) ([]ImageList, *APIError) {
+	resp, apiErr := ListImagesByNode(ctx, s.c, &ListImagesByNodeArg{NodeId: nodeId})
+	return deref(resp), apiErr
+}
+
+func (s nodesService) Healthcheck(ctx context.Context) (*RunHealthcheckResponse, *APIError) {
+	return RunHealthcheck(ctx, s.c, &RunHealthcheckArg{})
+}
+
+type instancesService struct{ c *Client }
+
+func (s instancesService) List(ctx context.Context, scope InstanceScope) ([]InstanceList, *APIError) {
+	arg := &GetInstancesByIdArg{}
+	if scope != nil {
+		scope(arg)
+	}
+	resp, apiErr := GetInstancesById(ctx, s.c, arg)
+	return deref(resp), apiErr
+}
+
+func (s instancesService) Get(ctx context.Context, nodeId, instanceId string) (*InstanceDetail, *APIError) {
+	return GetInstanceById(ctx, s.c, &GetInstanceByIdArg{NodeId: nodeId, InstanceId: instanceId})
+}
+
+func (s instancesService) Create(ctx context.Context, arg *CreateInstanceArg) (*CreateInstanceResponse, *APIError) {
+	return CreateInstance(ctx, s.c, arg)
+}
+
+func (s instancesService) Delete(ctx context.Context, arg *DeleteInstanceArg) (*DeleteInstanceResponse, *APIError) {
+	return DeleteInstance(ctx, s.c, arg)
+}
+
+func (s instancesService) Migrate(ctx context.Context, arg *MigrateInstanceArg) (*MigrateInstanceResponse, *APIError) {
+	return MigrateInstance(ctx, s.c, arg)
+}
+
+func (s instancesService) Power(ctx context.Context, nodeId, instanceId string, action enum.InstancePowerAction) (*PowerInstanceResponse, *APIError) {
+	return PowerInstance(ctx, s.c, &PowerInstanceArg{NodeId: nodeId, InstanceId: instanceId, Action: action})
+}
+
+type snapshotsService struct{ c *Client }
+
+func (s snapshotsService) List(ctx context.Context, nodeId, instanceId string) ([]SnapshotDetail, *APIError) {
+	resp, apiErr := ListInstanceSnapshots(ctx, s.c, &ListInstanceSnapshotsArg{NodeId: nodeId, InstanceId: instanceId})
+	return deref(resp), apiErr
+}
+
+func (s snapshotsService) Create(ctx context.Context, arg *CreateInstanceSnapshotArg) (*CreateInstanceSnapshotResponse, *APIError) {
+	return CreateInstanceSnapshot(ctx, s.c, arg)
+}
+
+func (s snapshotsService) Revert(ctx context.Context, nodeId, instanceId, snapshotId string) (*RevertInstanceSnapshotResponse, *APIError) {
+	return RevertInstanceSnapshot(ctx, s.c, &RevertInstanceSnapshotArg{NodeId: nodeId, InstanceId: instanceId, SnapshotId: snapshotId})
+}
+
+func (s snapshotsService) Delete(ctx context.Context, nodeId, instanceId, snapshotId string) (*DeleteInstanceSnapshotResponse, *APIError) {
+	return DeleteInstanceSnapshot(ctx, s.c, &DeleteInstanceSnapshotArg{NodeId: nodeId, InstanceId: instanceId, SnapshotId: snapshotId})
+}
+
+type tasksService struct{ c *Client }
+
+func (s tasksService) Get(ctx context.Context, taskId string) (*TaskDetail, *APIError) {
+	return GetTask(ctx, s.c, &GetTaskArg{TaskId: taskId})
+}
+
+func (s tasksService) List(ctx context.Context, nodeId string, limit int) ([]TaskDetail, *APIError) {
+	resp, apiErr := ListTasks(ctx, s.c, &ListTasksArg{NodeId: nodeId, Limit: limit})
+	return deref(resp), apiErr
+}
+
+func (s tasksService) Wait(ctx context.Context, taskId string, pollInterval time.Duration) (*TaskDetail, *APIError) {
+	return WaitForTask(ctx, s.c, taskId, pollInterval)
+}
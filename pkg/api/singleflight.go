@@ -0,0 +1,117 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+)
+
+// flightGroup coalesces concurrent identical buffered GET requests (see
+// Client.Get) so that they share a single in-flight round trip.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done    chan struct{}
+	waiters int
+	cancel  context.CancelFunc
+	// X-Request-ID of the first caller, the one PCE sees
+	requestID string
+
+	// Set before done is closed
+	resp *http.Response
+	body json.RawMessage
+	err  *APIError
+}
+
+// flightKey identifies a request by method, URL, caller identity and
+// conditional headers.
+func flightKey(req *http.Request) string {
+	return req.Method + " " + cacheKey(req) + " " + req.Header.Get("If-None-Match") + " " + req.Header.Get("If-Modified-Since")
+}
+
+// do runs fn once per key among concurrent callers. Each caller may give up
+// through its own context; the shared request is cancelled only once every
+// caller has given up. The shared request carries the first caller's
+// X-Request-ID, which errors returned to the others report in RequestID. A
+// nil group runs fn directly.
+func (g *flightGroup) do(req *http.Request, fn func(*http.Request) (*http.Response, json.RawMessage, *APIError)) (*http.Response, json.RawMessage, *APIError) {
+	if g == nil {
+		return fn(req)
+	}
+	key := flightKey(req)
+	ctx := req.Context()
+
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	call, ok := g.calls[key]
+	if ok {
+		call.waiters++
+	} else {
+		// Detach from the first caller so its cancellation doesn't fail the others
+		sharedCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &flightCall{done: make(chan struct{}), waiters: 1, cancel: cancel, requestID: req.Header.Get(RequestIDHeader)}
+		g.calls[key] = call
+		go func() {
+			call.resp, call.body, call.err = fn(req.WithContext(sharedCtx))
+			cancel()
+			g.mu.Lock()
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+			g.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		// Only the first caller's request ID reaches PCE
+		shared := ""
+		if id := req.Header.Get(RequestIDHeader); call.requestID != id {
+			shared = call.requestID
+			slog.DebugContext(ctx, "PCE API request shared with another caller", "request_id", id, "shared_request_id", shared)
+		}
+		if call.err != nil {
+			// The body is kept for truncated responses
+			errCopy := *call.err
+			errCopy.RequestID = shared
+			return call.resp, call.body, &errCopy
+		}
+		return call.resp, call.body, nil
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			call.cancel()
+			// Let new callers start a fresh request
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, nil, WrapAPIError(ctx.Err(), 0, "request failed")
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// blockingServer returns a client whose GETs block until release is closed
+// or the request is cancelled, which is reported on cancelled.
+func blockingServer(t *testing.T) (c *Client, started chan struct{}, release chan struct{}, cancelled chan struct{}) {
+	started = make(chan struct{}, 1)
+	release = make(chan struct{})
+	cancelled = make(chan struct{}, 1)
+	c = newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		select {
+		case <-release:
+			fmt.Fprint(w, `{"name":"node-1"}`)
+		case <-r.Context().Done():
+			cancelled <- struct{}{}
+		}
+	}))
+	return c, started, release, cancelled
+}
+
+// waitForWaiters waits until the single in-flight call has n waiters.
+func waitForWaiters(t *testing.T, g *flightGroup, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		g.mu.Lock()
+		for _, call := range g.calls {
+			if call.waiters == n {
+				g.mu.Unlock()
+				return
+			}
+		}
+		g.mu.Unlock()
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d waiters", n)
+}
+
+type getResult struct {
+	node   testNode
+	apiErr *APIError
+}
+
+func goGet(ctx context.Context, c *Client) chan getResult {
+	ch := make(chan getResult, 1)
+	go func() {
+		var res getResult
+		res.apiErr = c.Get(ctx, "/v1/nodes/node-1", nil, &res.node)
+		ch <- res
+	}()
+	return ch
+}
+
+func TestFlightSurvivesFirstCallerLeaving(t *testing.T) {
+	c, started, release, cancelled := blockingServer(t)
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	defer cancel1()
+	first := goGet(ctx1, c)
+	<-started
+	second := goGet(context.Background(), c)
+	waitForWaiters(t, c.flights, 2)
+
+	cancel1()
+	if res := <-first; res.apiErr == nil {
+		t.Error("first caller succeeded after cancelling, want an error")
+	}
+	select {
+	case <-cancelled:
+		t.Fatal("shared request cancelled while a caller was still waiting")
+	default:
+	}
+
+	close(release)
+	res := <-second
+	if res.apiErr != nil || res.node.Name != "node-1" {
+		t.Errorf("second caller got %+v, %v; want node-1", res.node, res.apiErr)
+	}
+}
+
+func TestFlightCancelledWhenAllCallersLeave(t *testing.T) {
+	c, started, release, cancelled := blockingServer(t)
+	defer close(release)
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	defer cancel1()
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+	first := goGet(ctx1, c)
+	<-started
+	second := goGet(ctx2, c)
+	waitForWaiters(t, c.flights, 2)
+
+	cancel1()
+	<-first
+	cancel2()
+	<-second
+
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("shared request not cancelled after every caller left")
+	}
+
+	// A new caller starts a fresh request rather than joining the cancelled one
+	third := goGet(context.Background(), c)
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("new caller did not start a fresh request")
+	}
+	release <- struct{}{}
+	if res := <-third; res.apiErr != nil {
+		t.Errorf("new caller: %v", res.apiErr)
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+type ListInstanceSnapshotsArg struct {
+	InstanceId string
+	NodeId     string
+}
+type ListInstanceSnapshotsResponse = []SnapshotDetail
+
+func ListInstanceSnapshots(ctx context.Context, c *Client, arg *ListInstanceSnapshotsArg) (*ListInstanceSnapshotsResponse, *APIError) {
+	if arg == nil || arg.NodeId == "" || arg.InstanceId == "" {
+		return nil, NewAPIError(400, "node_id and instance_id are required")
+	}
+
+	path := c.ExpandPath("/v1/instances/{instance_id}/snapshots", map[string]string{"instance_id": arg.InstanceId})
+
+	query := make(url.Values)
+	query.Set("node_id", arg.NodeId)
+
+	var resp ListInstanceSnapshotsResponse
+	if apiErr := c.Get(ctx, path, query, &resp); apiErr != nil {
+		return partialList(&resp, apiErr)
+	}
+	return &resp, nil
+}
+
+type CreateInstanceSnapshotArg struct {
+	InstanceId  string `json:"-"`
+	NodeId      string `json:"-"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Also save the memory state of a running QEMU instance, so that reverting
+	// resumes it where it was
+	IncludeMemory bool `json:"memory"`
+}
+type CreateInstanceSnapshotResponse struct {
+	SnapshotId string `json:"snapshot_id"`
+	TaskId     string `json:"task_id"`
+}
+
+// CreateInstanceSnapshot starts taking a snapshot; track it with the returned
+// task. See CheckCreateInstanceSnapshot to check the instance first.
+func CreateInstanceSnapshot(ctx context.Context, c *Client, arg *CreateInstanceSnapshotArg) (*CreateInstanceSnapshotResponse, *APIError) {
+	if arg == nil || arg.NodeId == "" || arg.InstanceId == "" || arg.Name == "" {
+		return nil, NewAPIError(400, "node_id, instance_id and name are required")
+	}
+
+	path := c.ExpandPath("/v1/instances/{instance_id}/snapshots", map[string]string{"instance_id": arg.InstanceId})
+
+	query := make(url.Values)
+	query.Set("node_id", arg.NodeId)
+
+	payload, err := json.Marshal(arg)
+	if err != nil {
+		return nil, NewAPIError(500, "failed to encode request payload")
+	}
+
+	var resp CreateInstanceSnapshotResponse
+	if apiErr := c.Post(ctx, path, query, bytes.NewReader(payload), &resp); apiErr != nil {
+		return nil, apiErr
+	}
+	return &resp, nil
+}
+
+type RevertInstanceSnapshotArg struct {
+	InstanceId string
+	NodeId     string
+	SnapshotId string
+}
+type RevertInstanceSnapshotResponse struct {
+	TaskId string `json:"task_id"`
+}
+
+// RevertInstanceSnapshot starts reverting the instance to a snapshot,
+// discarding its current state; track it with the returned task.
+func RevertInstanceSnapshot(ctx context.Context, c *Client, arg *RevertInstanceSnapshotArg) (*RevertInstanceSnapshotResponse, *APIError) {
+	if arg == nil || arg.NodeId == "" || arg.InstanceId == "" || arg.SnapshotId == "" {
+		return nil, NewAPIError(400, "node_id, instance_id and snapshot_id are required")
+	}
+
+	path := c.ExpandPath("/v1/instances/{instance_id}/snapshots/{snapshot_id}/revert", map[string]string{
+		"instance_id": arg.InstanceId,
+		"snapshot_id": arg.SnapshotId,
+	})
+
+	query := make(url.Values)
+	query.Set("node_id", arg.NodeId)
+
+	var resp RevertInstanceSnapshotResponse
+	if apiErr := c.Post(ctx, path, query, nil, &resp); apiErr != nil {
+		return nil, apiErr
+	}
+	return &resp, nil
+}
+
+type DeleteInstanceSnapshotArg struct {
+	InstanceId string
+	NodeId     string
+	SnapshotId string
+}
+type DeleteInstanceSnapshotResponse struct {
+	TaskId string `json:"task_id"`
+}
+
+// DeleteInstanceSnapshot starts deleting a snapshot; its children are
+// re-parented to its parent. Track it with the returned task.
+func DeleteInstanceSnapshot(ctx context.Context, c *Client, arg *DeleteInstanceSnapshotArg) (*DeleteInstanceSnapshotResponse, *APIError) {
+	if arg == nil || arg.NodeId == "" || arg.InstanceId == "" || arg.SnapshotId == "" {
+		return nil, NewAPIError(400, "node_id, instance_id and snapshot_id are required")
+	}
+
+	path := c.ExpandPath("/v1/instances/{instance_id}/snapshots/{snapshot_id}", map[string]string{
+		"instance_id": arg.InstanceId,
+		"snapshot_id": arg.SnapshotId,
+	})
+
+	query := make(url.Values)
+	query.Set("node_id", arg.NodeId)
+
+	var resp DeleteInstanceSnapshotResponse
+	if apiErr := c.Delete(ctx, path, query, &resp); apiErr != nil {
+		return nil, apiErr
+	}
+	return &resp, nil
+}
+
+// SnapshotTreeNode is a snapshot with the snapshots taken from it.
+type SnapshotTreeNode struct {
+	SnapshotDetail
+	Children []*SnapshotTreeNode `json:"children,omitempty"`
+}
+
+// SnapshotTree arranges snapshots by parent. Snapshots whose parent is not
+// listed are roots, as are those on a parent cycle (inconsistent data), which
+// would be unreachable otherwise. Order is preserved among siblings.
+func SnapshotTree(snapshots []SnapshotDetail) []*SnapshotTreeNode {
+	nodes := make(map[string]*SnapshotTreeNode, len(snapshots))
+	for _, s := range snapshots {
+		nodes[s.Id] = &SnapshotTreeNode{SnapshotDetail: s}
+	}
+	onCycle := parentCycles(nodes)
+
+	var roots []*SnapshotTreeNode
+	for _, s := range snapshots {
+		node := nodes[s.Id]
+		if parent, ok := nodes[s.ParentId]; ok && !onCycle[s.Id] {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return roots
+}
+
+// parentCycles returns the ids of the nodes on a parent cycle, including
+// snapshots listed as their own parent.
+func parentCycles(nodes map[string]*SnapshotTreeNode) map[string]bool {
+	onCycle := make(map[string]bool)
+	done := make(map[string]bool)
+	for id := range nodes {
+		// Follow the parents until a root, a node already walked or a cycle
+		var path []string
+		index := make(map[string]int)
+		for cur := id; !done[cur]; {
+			if i, ok := index[cur]; ok {
+				for _, c := range path[i:] {
+					onCycle[c] = true
+				}
+				break
+			}
+			index[cur] = len(path)
+			path = append(path, cur)
+			parent := nodes[cur].ParentId
+			if _, ok := nodes[parent]; !ok {
+				break
+			}
+			cur = parent
+		}
+		for _, c := range path {
+			done[c] = true
+		}
+	}
+	return onCycle
+}
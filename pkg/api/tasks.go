@@ -0,0 +1,106 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type GetTaskArg struct {
+	TaskId string
+}
+type GetTaskResponse = TaskDetail
+
+func GetTask(ctx context.Context, c *Client, arg *GetTaskArg) (*GetTaskResponse, *APIError) {
+	if arg == nil || arg.TaskId == "" {
+		return nil, NewAPIError(400, "task_id is required")
+	}
+
+	path := c.ExpandPath("/v1/tasks/{task_id}", map[string]string{"task_id": arg.TaskId})
+
+	var resp GetTaskResponse
+	if apiErr := c.Get(ctx, path, nil, &resp); apiErr != nil {
+		return nil, apiErr
+	}
+	return &resp, nil
+}
+
+type ListTasksArg struct {
+	// Optional filters
+	NodeId string
+	Limit  int
+}
+type ListTasksResponse = []TaskDetail
+
+func ListTasks(ctx context.Context, c *Client, arg *ListTasksArg) (*ListTasksResponse, *APIError) {
+	query := make(url.Values)
+	if arg != nil {
+		if arg.NodeId != "" {
+			query.Set("node_id", arg.NodeId)
+		}
+		if arg.Limit > 0 {
+			query.Set("limit", strconv.Itoa(arg.Limit))
+		}
+	}
+
+	path := "/v1/tasks"
+
+	var resp ListTasksResponse
+	if apiErr := c.Get(ctx, path, query, &resp); apiErr != nil {
+		return partialList(&resp, apiErr)
+	}
+	return &resp, nil
+}
+
+// WaitForTask polls the task every pollInterval until it reaches a terminal
+// state or ctx is done. The last observed task is returned together with the
+// context error if the wait is cut short.
+func WaitForTask(ctx context.Context, c *Client, id string, pollInterval time.Duration) (*GetTaskResponse, *APIError) {
+	if id == "" {
+		return nil, NewAPIError(400, "task_id is required")
+	}
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var last *GetTaskResponse
+	for {
+		task, apiErr := GetTask(ctx, c, &GetTaskArg{TaskId: id})
+		if apiErr != nil {
+			// The poll itself may be cut short
+			if last != nil && ctx.Err() != nil {
+				return last, WrapAPIError(ctx.Err(), 0, "waiting for task")
+			}
+			return nil, apiErr
+		}
+		last = task
+		if task.State.IsTerminal() {
+			return task, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return task, WrapAPIError(ctx.Err(), 0, "waiting for task")
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Timeouts are the timeouts of single HTTP attempts, applied through the
+// request context. Zero values fall back to Default; a zero Default disables
+// the timeout.
+type Timeouts struct {
+	Default time.Duration
+	// Reads (GET)
+	Read time.Duration
+	// Mutations (POST/PUT/DELETE), e.g. for long-running synchronous operations
+	Mutation time.Duration
+}
+
+type requestTimeoutKey struct{}
+
+// WithRequestTimeout overrides the client's Timeouts for requests made with
+// the returned context, e.g. to give a long-running tool call more time. Zero
+// leaves the requests bounded by the context deadline only.
+func WithRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutKey{}, timeout)
+}
+
+// forRequest returns the timeout of an attempt of req.
+func (t Timeouts) forRequest(req *http.Request) time.Duration {
+	if d, ok := req.Context().Value(requestTimeoutKey{}).(time.Duration); ok {
+		return d
+	}
+	d := t.Mutation
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		d = t.Read
+	}
+	if d <= 0 {
+		d = t.Default
+	}
+	return d
+}
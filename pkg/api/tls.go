@@ -0,0 +1,89 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// certReloader serves a client certificate from disk, reloading it whenever
+// the certificate or key file changes.
+type certReloader struct {
+	certPath string
+	keyPath  string
+
+	mu       sync.Mutex
+	cert     *tls.Certificate
+	certTime time.Time
+	keyTime  time.Time
+}
+
+func newCertReloader(certPath, keyPath string) (*certReloader, error) {
+	r := &certReloader{certPath: certPath, keyPath: keyPath}
+	if _, err := r.certificate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// certificate returns the current certificate, reloading it if either file
+// has been modified since it was last read.
+func (r *certReloader) certificate() (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// A missing file during rotation keeps the previous pair in service
+	certInfo, err := os.Stat(r.certPath)
+	if err != nil {
+		if r.cert != nil {
+			return r.cert, nil
+		}
+		return nil, fmt.Errorf("client certificate: %w", err)
+	}
+	keyInfo, err := os.Stat(r.keyPath)
+	if err != nil {
+		if r.cert != nil {
+			return r.cert, nil
+		}
+		return nil, fmt.Errorf("client key: %w", err)
+	}
+
+	if r.cert != nil && certInfo.ModTime().Equal(r.certTime) && keyInfo.ModTime().Equal(r.keyTime) {
+		return r.cert, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certPath, r.keyPath)
+	if err != nil {
+		// Keep serving the previous pair if the files are mid-rotation
+		if r.cert != nil {
+			return r.cert, nil
+		}
+		return nil, fmt.Errorf("failed to load client certificate: %w", err)
+	}
+	r.cert = &cert
+	r.certTime = certInfo.ModTime()
+	r.keyTime = keyInfo.ModTime()
+	return r.cert, nil
+}
+
+// GetClientCertificate implements tls.Config.GetClientCertificate.
+func (r *certReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return r.certificate()
+}
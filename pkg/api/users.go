@@ -25,6 +25,7 @@ import (
 
 type ListUsersInOrganizationByIdArg struct {
 	OrganizationId string
+	PageArg
 }
 type ListUsersInOrganizationByIdResponse = []UserList
 
@@ -36,10 +37,11 @@ func ListUsersInOrganizationById(ctx context.Context, c *Client, arg *ListUsersI
 	path := "/v1/users"
 	query := make(url.Values)
 	query.Set("organization_id", arg.OrganizationId)
+	arg.PageArg.apply(query)
 
 	var resp ListUsersInOrganizationByIdResponse
 	if apiErr := c.Get(ctx, path, query, &resp); apiErr != nil {
-		return nil, apiErr
+		return partialList(&resp, apiErr)
 	}
 	return &resp, nil
 }
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a PCE release version, e.g. "1.4.2".
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// ParseVersion parses a PCE version such as "1.4", "1.4.2" or "v1.4.2-rc1".
+// Pre-release and build suffixes are ignored.
+func ParseVersion(s string) (Version, error) {
+	core := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(core, "-+ "); i >= 0 {
+		core = core[:i]
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid PCE version %q", s)
+	}
+	var nums [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid PCE version %q", s)
+		}
+		nums[i] = n
+	}
+	return Version{Major: nums[0], Minor: nums[1], Patch: nums[2]}, nil
+}
+
+// IsZero reports whether v is the zero value, i.e. unknown.
+func (v Version) IsZero() bool {
+	return v == Version{}
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// VersionInfo describes the connected PCE.
+type VersionInfo struct {
+	// Detected version; zero if PCE reported none or an unparsable one
+	Version Version
+	// Raw version string reported by PCE
+	RawVersion string
+	// Node serving the API
+	NodeId string
+}
+
+// DetectVersion detects the PCE version through the healthcheck and the node
+// serving the API.
+func DetectVersion(ctx context.Context, c *Client) (*VersionInfo, *APIError) {
+	health, apiErr := RunHealthcheck(ctx, c, &RunHealthcheckArg{})
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	node, apiErr := GetNodeById(ctx, c, &GetNodeByIdArg{NodeId: health.Id})
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	info := &VersionInfo{
+		RawVersion: node.PceVersion,
+		NodeId:     health.Id,
+	}
+	if v, err := ParseVersion(node.PceVersion); err == nil {
+		info.Version = v
+	}
+	return info, nil
+}
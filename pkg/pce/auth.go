@@ -0,0 +1,113 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pce
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/PextraCloud/pce-mcp/internal/session"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func Login() (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("login",
+		mcp.WithDescription("Log in to the Pextra CloudEnvironment (PCE) with a username and password. The resulting PCE session is used by every other tool in this MCP session, until logout. Use this tool when other tools fail with 'unauthorized' errors. For accounts with multi-factor authentication, ask the user for the current code from their authenticator app and pass it as totp."),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:          "Login",
+			ReadOnlyHint:   mcp.ToBoolPtr(false),
+			IdempotentHint: mcp.ToBoolPtr(true),
+		}),
+		mcp.WithString("username",
+			mcp.Required(),
+			mcp.Description("PCE username"),
+		),
+		mcp.WithString("password",
+			mcp.Required(),
+			mcp.Description("PCE password. It is only sent to PCE, never stored or logged."),
+		),
+		mcp.WithString("totp",
+			mcp.Pattern("^[0-9]{6,8}$"),
+			mcp.Description("Current multi-factor authentication (TOTP) code, for accounts that require it"),
+		),
+	), handleLogin
+}
+
+type loginResult struct {
+	LoggedIn  bool   `json:"logged_in"`
+	Username  string `json:"username"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+func handleLogin(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	username, err := requiredParam[string](req, "username")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	password, err := requiredParam[string](req, "password")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	totp, err := optionalParam[string](req, "totp")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	s := server.ClientSessionFromContext(ctx)
+	if s == nil {
+		return mcp.NewToolResultError("missing session context"), nil
+	}
+
+	expires, loginErr := session.Login(ctx, s.SessionID(), username, password, totp)
+	if loginErr != nil {
+		return toolError(ctx, req, loginErr), nil
+	}
+
+	result := &loginResult{
+		LoggedIn: true,
+		Username: username,
+	}
+	if !expires.IsZero() {
+		result.ExpiresAt = expires.UTC().Format(time.RFC3339)
+	}
+	return mcp.NewToolResultJSON(result)
+}
+
+func Logout() (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("logout",
+		mcp.WithDescription("Log out of the Pextra CloudEnvironment (PCE) session created with login, invalidating its token. Other tools then fall back to the credentials this MCP server was started with, if any."),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:          "Logout",
+			ReadOnlyHint:   mcp.ToBoolPtr(false),
+			IdempotentHint: mcp.ToBoolPtr(true),
+		}),
+	), handleLogout
+}
+
+func handleLogout(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s := server.ClientSessionFromContext(ctx)
+	if s == nil {
+		return mcp.NewToolResultError("missing session context"), nil
+	}
+
+	username := session.Username(s.SessionID())
+	if logoutErr := session.Logout(ctx, s.SessionID()); logoutErr != nil {
+		return toolError(ctx, req, logoutErr), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Logged out %s", username)), nil
+}
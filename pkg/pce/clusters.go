@@ -45,14 +45,14 @@ func handleGetClusterHardwareById(ctx context.Context, req mcp.CallToolRequest)
 
 	client, err := clientForRequest(ctx, req)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return toolError(ctx, req, err), nil
 	}
 
 	hardware, getErr := api.GetClusterHardwareById(ctx, client, &api.GetClusterHardwareByIdArg{
 		ClusterId: clusterId,
 	})
 	if getErr != nil {
-		return mcp.NewToolResultError(getErr.Error()), nil
+		return toolError(ctx, req, getErr), nil
 	}
 
 	return mcp.NewToolResultJSON(hardware)
@@ -80,14 +80,14 @@ func handleGetClusterLicensingById(ctx context.Context, req mcp.CallToolRequest)
 
 	client, err := clientForRequest(ctx, req)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return toolError(ctx, req, err), nil
 	}
 
 	license, getErr := api.GetClusterLicensingById(ctx, client, &api.GetClusterLicensingByIdArg{
 		ClusterId: clusterId,
 	})
 	if getErr != nil {
-		return mcp.NewToolResultError(getErr.Error()), nil
+		return toolError(ctx, req, getErr), nil
 	}
 	return mcp.NewToolResultJSON(license)
 }
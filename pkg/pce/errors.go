@@ -0,0 +1,143 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pce
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/PextraCloud/pce-mcp/pkg/api"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// idFormats maps id parameters to the format PCE expects.
+var idFormats = map[string]string{
+	"organization_id": "org-<xxx>",
+	"cluster_id":      "cls-<xxx>",
+	"node_id":         "node-<xxx>",
+	"target_node_id":  "node-<xxx>",
+	"instance_id":     "inst-<xxx>",
+	"user_id":         "user-<xxx>",
+}
+
+// toolErrorEnvelope is returned to the client for failed PCE API calls.
+type toolErrorEnvelope struct {
+	Error toolErrorDetail `json:"error"`
+}
+
+type toolErrorDetail struct {
+	Code      string `json:"code"`
+	Status    int    `json:"status,omitempty"`
+	Retryable bool   `json:"retryable"`
+	// Seconds to wait before retrying, when known
+	RetryAfter float64 `json:"retry_after_seconds,omitempty"`
+	Message    string  `json:"message"`
+	Hint       string  `json:"hint,omitempty"`
+	// Sent to PCE as X-Request-ID, for correlating with its audit trail
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// toolError translates an error from a PCE API call into an error result with
+// a JSON envelope the model can act upon.
+func toolError(ctx context.Context, req mcp.CallToolRequest, err error) *mcp.CallToolResult {
+	detail := toolErrorDetail{
+		Code:      "internal",
+		Message:   err.Error(),
+		RequestID: api.RequestIDFromContext(ctx),
+	}
+
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		detail.Status = apiErr.Status
+		detail.Retryable = apiErr.IsRetryable()
+		detail.RetryAfter = math.Ceil(apiErr.RetryAfter.Seconds())
+		detail.Code, detail.Hint = classifyAPIError(req, apiErr)
+		if apiErr.RequestID != "" {
+			// The request PCE saw was another call's
+			detail.RequestID = apiErr.RequestID
+		}
+	}
+
+	envelope := toolErrorEnvelope{Error: detail}
+	b, marshalErr := json.Marshal(envelope)
+	if marshalErr != nil {
+		return mcp.NewToolResultError(err.Error())
+	}
+	result := mcp.NewToolResultStructured(envelope, string(b))
+	result.IsError = true
+	return result
+}
+
+// classifyAPIError returns an error code and a remediation hint.
+func classifyAPIError(req mcp.CallToolRequest, e *api.APIError) (string, string) {
+	switch {
+	case errors.Is(e, api.ErrSessionRenewed):
+		return "session_expired", "The PCE session had expired and has been renewed. PCE rejected the request without processing it; retry it as is."
+	case errors.Is(e, api.ErrSessionExpired):
+		return "session_expired", "The PCE session expired and could not be renewed. Call login to authenticate again, then retry."
+	case errors.Is(e, api.ErrMFARequired):
+		return "mfa_required", "Ask the user for the current code from their authenticator app and call login again with totp set."
+	case errors.Is(e, api.ErrPreflightFailed):
+		return "preflight_failed", "The request does not fit the current state of PCE and was not sent. Address the problems listed in the message (e.g., request fewer vCPUs or less memory, pick another storage pool, image or node, or stop the instance first) and retry."
+	case e.IsUnauthorized():
+		return "unauthorized", "The PCE credentials are missing, invalid or expired. Call login to authenticate, then retry."
+	case e.IsForbidden():
+		return "forbidden", "The authenticated PCE user lacks permission for this operation. Ask the user to grant access or use another account."
+	case e.IsNotFound():
+		return "not_found", idHint(req, "The referenced resource does not exist or is not visible to the current user")
+	case e.IsConflict():
+		return "conflict", "The resource is in a state that does not allow this operation (e.g., it is not empty or already exists). Inspect it before retrying."
+	case e.IsBadRequest():
+		return "invalid_argument", idHint(req, "PCE rejected the tool arguments")
+	case e.IsRateLimited() && errors.Is(e, api.ErrRateLimited):
+		return "rate_limited", fmt.Sprintf("Too many PCE API requests from this server; the request was not sent. Retry after %.0fs, and avoid calling tools in a tight loop.", math.Ceil(e.RetryAfter.Seconds()))
+	case e.IsRateLimited():
+		return "rate_limited", "PCE is throttling requests. Wait before retrying."
+	case e.IsResponseTooLarge():
+		return "response_too_large", "The PCE response exceeded the size limit. Request less data, e.g. a smaller page_size, or ask the user to raise --max-response-size."
+	case errors.Is(e, api.ErrCircuitOpen):
+		return "unavailable", "PCE is unreachable and requests are failing fast. Tell the user instead of retrying; server_status reports when it recovers."
+	case e.IsTimeout():
+		return "timeout", "PCE did not respond in time. Retry later, or check whether the operation completed before repeating it."
+	case e.Status == 0 && e.IsRetryable():
+		return "unavailable", "PCE could not be reached. Retry later, and tell the user if the problem persists."
+	case e.Status >= 500:
+		return "unavailable", "PCE failed to process the request. Retry later, and tell the user if the problem persists."
+	}
+	return "api_error", ""
+}
+
+// idHint appends the expected format of every id argument in req to lead.
+func idHint(req mcp.CallToolRequest, lead string) string {
+	var checks []string
+	for name, value := range req.GetArguments() {
+		format, ok := idFormats[name]
+		if !ok {
+			continue
+		}
+		checks = append(checks, fmt.Sprintf("%s %q (expected format %s)", name, value, format))
+	}
+	if len(checks) == 0 {
+		return lead + "."
+	}
+	sort.Strings(checks)
+	return fmt.Sprintf("%s. Check %s.", lead, strings.Join(checks, ", "))
+}
@@ -34,11 +34,15 @@ func GetImages() (mcp.Tool, server.ToolHandlerFunc) {
 			mcp.Required(),
 			mcp.Description("Unique node id (format: node-<xxx>)"),
 		),
+		pageNum,
+		pageSize,
+		pageCursor,
 	), handleGetImages
 }
 
 type getImagesResult struct {
-	Images *api.ListImagesByNodeResponse `json:"images"`
+	Images []api.ImageList `json:"images"`
+	pageInfo
 }
 
 func handleGetImages(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -46,20 +50,28 @@ func handleGetImages(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToo
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	page, err := pageArgFromRequest(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	var client *api.Client
 	if client, err = clientForRequest(ctx, req); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return toolError(ctx, req, err), nil
 	}
 
-	images, listErr := api.ListImagesByNode(ctx, client, &api.ListImagesByNodeArg{
-		NodeId: nodeId,
+	p, listErr := api.FetchPage("/v1/nodes/{node_id}/images/images", page, func(page api.PageArg) (*[]api.ImageList, *api.APIError) {
+		return api.ListImagesByNode(ctx, client, &api.ListImagesByNodeArg{
+			NodeId:  nodeId,
+			PageArg: page,
+		})
 	})
-	if listErr != nil {
-		return mcp.NewToolResultError(listErr.Error()), nil
+	if listErr != nil && !listErr.IsResponseTooLarge() {
+		return toolError(ctx, req, listErr), nil
 	}
 
 	return mcp.NewToolResultJSON(&getImagesResult{
-		Images: images,
+		Images:   p.Items,
+		pageInfo: newPageInfo(p, listErr),
 	})
 }
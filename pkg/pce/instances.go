@@ -29,7 +29,8 @@ const instancesHelpText = `\n\nInstances are virtual machines (QEMU/KVM) or cont
 They utilize the compute resources of the nodes to perform various tasks and services.` + hierarchyHelpText
 
 type getInstancesInNodeOrClusterResult struct {
-	Instances *api.GetInstancesByIdResponse `json:"instances"`
+	Instances []api.InstanceList `json:"instances"`
+	pageInfo
 }
 
 func GetInstancesInCluster() (mcp.Tool, server.ToolHandlerFunc) {
@@ -43,6 +44,9 @@ func GetInstancesInCluster() (mcp.Tool, server.ToolHandlerFunc) {
 			mcp.Required(),
 			mcp.Description("Unique cluster id (format: cls-<xxx>)"),
 		),
+		pageNum,
+		pageSize,
+		pageCursor,
 	), handleGetInstancesInCluster
 }
 
@@ -52,20 +56,29 @@ func handleGetInstancesInCluster(ctx context.Context, req mcp.CallToolRequest) (
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	client, err := clientForRequest(ctx, req)
+	page, err := pageArgFromRequest(req)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	instances, getErr := api.GetInstancesById(ctx, client, &api.GetInstancesByIdArg{
-		ClusterId: clusterId,
+	client, err := clientForRequest(ctx, req)
+	if err != nil {
+		return toolError(ctx, req, err), nil
+	}
+
+	p, getErr := api.FetchPage("/v1/instances", page, func(page api.PageArg) (*[]api.InstanceList, *api.APIError) {
+		return api.GetInstancesById(ctx, client, &api.GetInstancesByIdArg{
+			ClusterId: clusterId,
+			PageArg:   page,
+		})
 	})
-	if getErr != nil {
-		return mcp.NewToolResultError(getErr.Error()), nil
+	if getErr != nil && !getErr.IsResponseTooLarge() {
+		return toolError(ctx, req, getErr), nil
 	}
 
 	return mcp.NewToolResultJSON(&getInstancesInNodeOrClusterResult{
-		Instances: instances,
+		Instances: p.Items,
+		pageInfo:  newPageInfo(p, getErr),
 	})
 }
 
@@ -80,6 +93,9 @@ func GetInstancesInNode() (mcp.Tool, server.ToolHandlerFunc) {
 			mcp.Required(),
 			mcp.Description("Unique node id (format: node-<xxx>)"),
 		),
+		pageNum,
+		pageSize,
+		pageCursor,
 	), handleGetInstancesInNode
 }
 
@@ -89,26 +105,316 @@ func handleGetInstancesInNode(ctx context.Context, req mcp.CallToolRequest) (*mc
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
+	page, err := pageArgFromRequest(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	client, err := clientForRequest(ctx, req)
+	if err != nil {
+		return toolError(ctx, req, err), nil
+	}
+
+	p, getErr := api.FetchPage("/v1/instances", page, func(page api.PageArg) (*[]api.InstanceList, *api.APIError) {
+		return api.GetInstancesById(ctx, client, &api.GetInstancesByIdArg{
+			NodeId:  nodeId,
+			PageArg: page,
+		})
+	})
+	if getErr != nil && !getErr.IsResponseTooLarge() {
+		return toolError(ctx, req, getErr), nil
+	}
+
+	return mcp.NewToolResultJSON(&getInstancesInNodeOrClusterResult{
+		Instances: p.Items,
+		pageInfo:  newPageInfo(p, getErr),
+	})
+}
+
+func GetInstanceById() (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_instance_by_id",
+		mcp.WithDescription(fmt.Sprintf("Retrieve the full configuration (CPU, memory, disks, network interfaces) and the current runtime state (power state, uptime, resource usage, IP addresses) of a specific instance. Check the power state before calling power_instance.%s", instancesHelpText)),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Get Instance by ID",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+		mcp.WithString("node_id",
+			mcp.Required(),
+			mcp.Description("Unique node id (format: node-<xxx>)"),
+		),
+		mcp.WithString("instance_id",
+			mcp.Required(),
+			mcp.Description("Unique instance id (format: inst-<xxx>)"),
+		),
+	), handleGetInstanceById
+}
+
+func handleGetInstanceById(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	nodeId, err := requiredParam[string](req, "node_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	instanceId, err := requiredParam[string](req, "instance_id")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	instances, getErr := api.GetInstancesById(ctx, client, &api.GetInstancesByIdArg{
-		NodeId: nodeId,
+	client, err := clientForRequest(ctx, req)
+	if err != nil {
+		return toolError(ctx, req, err), nil
+	}
+
+	instance, getErr := api.GetInstanceById(ctx, client, &api.GetInstanceByIdArg{
+		NodeId:     nodeId,
+		InstanceId: instanceId,
 	})
 	if getErr != nil {
-		return mcp.NewToolResultError(getErr.Error()), nil
+		return toolError(ctx, req, getErr), nil
 	}
 
-	return mcp.NewToolResultJSON(&getInstancesInNodeOrClusterResult{
-		Instances: instances,
+	return mcp.NewToolResultJSON(instance)
+}
+
+func CreateInstance() (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("create_instance",
+		mcp.WithDescription(fmt.Sprintf("Create a new instance on a specific node from one of its images (see get_images). The request is first checked against the node hardware (vCPUs, memory) and the storage pool (availability, free space); nothing is created if a check fails. Returns the task_id of the creation, to be tracked with wait_for_task.%s", instancesHelpText)),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title: "Create Instance",
+		}),
+		mcp.WithString("node_id",
+			mcp.Required(),
+			mcp.Description("Unique node id (format: node-<xxx>)"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.MinLength(nameDefaultMinLength),
+			mcp.MaxLength(nameDefaultMaxLength),
+			mcp.Pattern(nameRegex(nameDefaultMinLength, nameDefaultMaxLength)),
+			mcp.Description("The name of the new instance."),
+		),
+		mcp.WithString("type",
+			mcp.Enum(
+				enum.InstanceTypeEnumQEMU.String(),
+				enum.InstanceTypeEnumLXC.String(),
+				enum.InstanceTypeEnumDocker.String(),
+				enum.InstanceTypeEnumPodman.String(),
+			),
+			mcp.Required(),
+			mcp.Description("Instance type: qemu = virtual machine, lxc = system container, docker/podman = application container. Must match the type of the image."),
+		),
+		mcp.WithString("image",
+			mcp.Required(),
+			mcp.Description("Name of the image to create the instance from, as returned by get_images for the node"),
+		),
+		mcp.WithString("storage_pool_id",
+			mcp.Required(),
+			mcp.Description("Unique id of the storage pool to hold the instance volumes, as returned by get_node_storagepools_by_id"),
+		),
+		mcp.WithNumber("cpu_sockets",
+			mcp.Min(1),
+			mcp.DefaultNumber(1),
+			mcp.Description("Number of CPU sockets. Default is 1."),
+		),
+		mcp.WithNumber("cpu_cores",
+			mcp.Min(1),
+			mcp.DefaultNumber(1),
+			mcp.Description("Number of cores per socket. Default is 1."),
+		),
+		mcp.WithNumber("cpu_threads",
+			mcp.Min(1),
+			mcp.DefaultNumber(1),
+			mcp.Description("Number of threads per core. Default is 1. The instance gets sockets x cores x threads vCPUs."),
+		),
+		mcp.WithNumber("memory_mb",
+			mcp.Required(),
+			mcp.Min(1),
+			mcp.Description("Memory in MB"),
+		),
+		mcp.WithBoolean("autostart",
+			mcp.DefaultBool(false),
+			mcp.Description("Whether to start the instance when the node boots. Default is false."),
+		),
+		mcp.WithNumber("boot_order",
+			mcp.Min(0),
+			mcp.DefaultNumber(0),
+			mcp.Description("Order in which autostarted instances are started, lowest first. Default is 0."),
+		),
+	), handleCreateInstance
+}
+
+func handleCreateInstance(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	arg := &api.CreateInstanceArg{}
+	var err error
+	if arg.NodeId, err = requiredParam[string](req, "node_id"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if arg.Name, err = requiredParam[string](req, "name"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	instanceType, err := requiredParam[string](req, "type")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	var ok bool
+	if arg.Type, ok = enum.ParseInstanceType(instanceType); !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid type %q", instanceType)), nil
+	}
+	if arg.Image, err = requiredParam[string](req, "image"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if arg.StoragePoolId, err = requiredParam[string](req, "storage_pool_id"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	memory, err := requiredParam[float64](req, "memory_mb")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	arg.MemoryMB = int(memory)
+
+	// CPU topology, defaulting to a single vCPU
+	for name, v := range map[string]*int{
+		"cpu_sockets": &arg.Cpu.Sockets,
+		"cpu_cores":   &arg.Cpu.Cores,
+		"cpu_threads": &arg.Cpu.Threads,
+	} {
+		n, err := optionalParam[float64](req, name)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		*v = max(1, int(n))
+	}
+	if arg.Autostart, err = optionalParam[bool](req, "autostart"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	bootOrder, err := optionalParam[float64](req, "boot_order")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	arg.BootOrder = int(bootOrder)
+
+	client, err := clientForRequest(ctx, req)
+	if err != nil {
+		return toolError(ctx, req, err), nil
+	}
+
+	if checkErr := api.CheckCreateInstance(ctx, client, arg); checkErr != nil {
+		return toolError(ctx, req, checkErr), nil
+	}
+	res, createErr := api.CreateInstance(ctx, client, arg)
+	if createErr != nil {
+		return toolError(ctx, req, createErr), nil
+	}
+
+	return mcp.NewToolResultJSON(struct {
+		Message    string `json:"message"`
+		InstanceId string `json:"instance_id"`
+		TaskId     string `json:"task_id"`
+	}{
+		Message:    "Instance creation started. Use wait_for_task with the task_id to confirm the result.",
+		InstanceId: res.InstanceId,
+		TaskId:     res.TaskId,
+	})
+}
+
+func DeleteInstance() (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_instance",
+		mcp.WithDescription("Delete an existing instance. The instance must be stopped (see get_instance_by_id and power_instance) unless force is set. Returns the task_id of the deletion, to be tracked with wait_for_task."),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Delete Instance",
+			DestructiveHint: mcp.ToBoolPtr(true),
+		}),
+		mcp.WithString("node_id",
+			mcp.Required(),
+			mcp.Description("Unique node id (format: node-<xxx>)"),
+		),
+		mcp.WithString("instance_id",
+			mcp.Required(),
+			mcp.Description("Unique instance id (format: inst-<xxx>)"),
+		),
+		mcp.WithBoolean("delete_volumes",
+			mcp.DefaultBool(false),
+			mcp.Description("Also delete the volumes (disks) attached to the instance, destroying their data. Default is false, which keeps them in their storage pools."),
+		),
+		mcp.WithBoolean("force",
+			mcp.DefaultBool(false),
+			mcp.Description("Delete the instance even if it is running, powering it off abruptly. Default is false. Only set this if the user explicitly asked for it."),
+		),
+		mcp.WithBoolean("are_you_sure",
+			mcp.Required(),
+			mcp.Description("A safety check to prevent accidental deletions. Must be set to true to proceed with deletion."),
+		),
+	), handleDeleteInstance
+}
+
+func handleDeleteInstance(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	nodeId, err := requiredParam[string](req, "node_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	instanceId, err := requiredParam[string](req, "instance_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	deleteVolumes, err := optionalParam[bool](req, "delete_volumes")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	force, err := optionalParam[bool](req, "force")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	areYouSure, err := requiredParam[bool](req, "are_you_sure")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if !areYouSure {
+		return mcp.NewToolResultError("Deletion not confirmed. Set 'are_you_sure' to true to proceed."), nil
+	}
+
+	client, err := clientForRequest(ctx, req)
+	if err != nil {
+		return toolError(ctx, req, err), nil
+	}
+
+	arg := &api.DeleteInstanceArg{
+		NodeId:        nodeId,
+		InstanceId:    instanceId,
+		DeleteVolumes: deleteVolumes,
+		Force:         force,
+	}
+	instance, checkErr := api.CheckDeleteInstance(ctx, client, arg)
+	if checkErr != nil {
+		return toolError(ctx, req, checkErr), nil
+	}
+	res, deleteErr := api.DeleteInstance(ctx, client, arg)
+	if deleteErr != nil {
+		return toolError(ctx, req, deleteErr), nil
+	}
+
+	var volumes []string
+	if deleteVolumes {
+		for _, disk := range instance.Disks {
+			volumes = append(volumes, disk.Id)
+		}
+	}
+	message := "Instance deletion started. Use wait_for_task with the task_id to confirm the result."
+	if len(volumes) > 0 {
+		message += " The volumes in volumes_to_delete are deleted only if the task succeeds."
+	}
+	return mcp.NewToolResultJSON(struct {
+		Message         string   `json:"message"`
+		TaskId          string   `json:"task_id"`
+		VolumesToDelete []string `json:"volumes_to_delete,omitempty"`
+	}{
+		Message:         message,
+		TaskId:          res.TaskId,
+		VolumesToDelete: volumes,
 	})
 }
 
 func PowerInstance() (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("power_instance",
-		mcp.WithDescription("Perform a power action on a specific instance (start, stop, restart, kill)"),
+		mcp.WithDescription("Perform a power action on a specific instance (start, stop, restart, kill). Use get_instance_by_id to check its current power state first."),
 		mcp.WithToolAnnotation(mcp.ToolAnnotation{
 			Title:        "Power Instance",
 			ReadOnlyHint: mcp.ToBoolPtr(false),
@@ -152,7 +458,7 @@ func handlePowerInstance(ctx context.Context, req mcp.CallToolRequest) (*mcp.Cal
 
 	client, err := clientForRequest(ctx, req)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return toolError(ctx, req, err), nil
 	}
 
 	res, powerErr := api.PowerInstance(ctx, client, &api.PowerInstanceArg{
@@ -161,14 +467,94 @@ func handlePowerInstance(ctx context.Context, req mcp.CallToolRequest) (*mcp.Cal
 		Action:     enum.InstancePowerAction(action),
 	})
 	if powerErr != nil {
-		return mcp.NewToolResultError(powerErr.Error()), nil
+		return toolError(ctx, req, powerErr), nil
 	}
 
 	return mcp.NewToolResultJSON(struct {
 		Message string `json:"message"`
 		TaskId  string `json:"task_id"`
 	}{
-		Message: "Power action initiated successfully",
+		Message: "Power action initiated successfully. Use wait_for_task or get_task_status with the task_id to confirm the result.",
 		TaskId:  res.TaskId,
 	})
 }
+
+func MigrateInstance() (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("migrate_instance",
+		mcp.WithDescription("Move an instance to another node of its cluster. A live migration keeps a running instance running; an offline migration needs a stopped instance. The target node is first checked (alive, same cluster, enough free memory, storage pool availability and free space); nothing is migrated if a check fails. For live migrations, CPU flags of the source node missing on the target and different CPU manufacturers are reported as warnings, since the instance may crash; the migration is then refused unless ignore_cpu_warnings is set. Returns the task_id of the migration, to be tracked with wait_for_task."),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Migrate Instance",
+			ReadOnlyHint: mcp.ToBoolPtr(false),
+		}),
+		mcp.WithString("node_id",
+			mcp.Required(),
+			mcp.Description("Unique id of the node the instance is on (format: node-<xxx>)"),
+		),
+		mcp.WithString("instance_id",
+			mcp.Required(),
+			mcp.Description("Unique instance id (format: inst-<xxx>)"),
+		),
+		mcp.WithString("target_node_id",
+			mcp.Required(),
+			mcp.Description("Unique id of the node to move the instance to (format: node-<xxx>), in the same cluster"),
+		),
+		mcp.WithBoolean("live",
+			mcp.DefaultBool(false),
+			mcp.Description("Migrate the instance while it runs. Default is false, which requires the instance to be stopped (see power_instance)."),
+		),
+		mcp.WithString("target_storage_pool_id",
+			mcp.Description("Unique id of a storage pool of the target node to move the instance volumes to, as returned by get_node_storagepools_by_id. Omit to keep the volumes where they are, e.g. on shared storage."),
+		),
+		mcp.WithBoolean("ignore_cpu_warnings",
+			mcp.DefaultBool(false),
+			mcp.Description("Migrate live even if the CPU of the target node is incompatible, which may crash the instance. Default is false. Only set this if the user accepted the warnings returned by a previous call."),
+		),
+	), handleMigrateInstance
+}
+
+func handleMigrateInstance(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	arg := &api.MigrateInstanceArg{}
+	var err error
+	if arg.NodeId, err = requiredParam[string](req, "node_id"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if arg.InstanceId, err = requiredParam[string](req, "instance_id"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if arg.TargetNodeId, err = requiredParam[string](req, "target_node_id"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if arg.Live, err = optionalParam[bool](req, "live"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if arg.TargetStoragePoolId, err = optionalParam[string](req, "target_storage_pool_id"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if arg.IgnoreCpuWarnings, err = optionalParam[bool](req, "ignore_cpu_warnings"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := clientForRequest(ctx, req)
+	if err != nil {
+		return toolError(ctx, req, err), nil
+	}
+
+	check, checkErr := api.CheckMigrateInstance(ctx, client, arg)
+	if checkErr != nil {
+		return toolError(ctx, req, checkErr), nil
+	}
+	res, migrateErr := api.MigrateInstance(ctx, client, arg)
+	if migrateErr != nil {
+		return toolError(ctx, req, migrateErr), nil
+	}
+
+	return mcp.NewToolResultJSON(struct {
+		Message string `json:"message"`
+		TaskId  string `json:"task_id"`
+		*api.MigrationCheck
+	}{
+		Message:        "Instance migration started. Use wait_for_task with the task_id to confirm the result, then refer to the instance with target_node_id.",
+		TaskId:         res.TaskId,
+		MigrationCheck: check,
+	})
+}
@@ -48,14 +48,14 @@ func handleGetNodeById(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallT
 
 	client, err := clientForRequest(ctx, req)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return toolError(ctx, req, err), nil
 	}
 
 	node, getErr := api.GetNodeById(ctx, client, &api.GetNodeByIdArg{
 		NodeId: nodeId,
 	})
 	if getErr != nil {
-		return mcp.NewToolResultError(getErr.Error()), nil
+		return toolError(ctx, req, getErr), nil
 	}
 
 	return mcp.NewToolResultJSON(node)
@@ -75,28 +75,32 @@ func handleGetCurrentNode(ctx context.Context, req mcp.CallToolRequest) (*mcp.Ca
 	client, err := clientForRequest(ctx, req)
 	if err != nil {
 		fmt.Println("Error retrieving session:", err)
-		return mcp.NewToolResultError(err.Error()), nil
+		return toolError(ctx, req, err), nil
+	}
+
+	node, getErr := currentNode(ctx, client)
+	if getErr != nil {
+		return toolError(ctx, req, getErr), nil
 	}
 
-	// Get node ID through healthcheck endpoint
+	return mcp.NewToolResultJSON(node)
+}
+
+// currentNode retrieves the node serving the API, identified through the
+// healthcheck endpoint. Concurrent callers share the underlying requests.
+func currentNode(ctx context.Context, client *api.Client) (*api.GetNodeByIdResponse, *api.APIError) {
 	health, healthErr := api.RunHealthcheck(ctx, client, &api.RunHealthcheckArg{})
 	if healthErr != nil {
-		return mcp.NewToolResultError(healthErr.Error()), nil
+		return nil, healthErr
 	}
 	// This should never happen
 	if !health.Healthy {
-		return mcp.NewToolResultError("Node is not healthy"), nil
+		return nil, api.NewAPIError(503, "node is not healthy")
 	}
 
-	nodeId := health.Id
-	node, getErr := api.GetNodeById(ctx, client, &api.GetNodeByIdArg{
-		NodeId: nodeId,
+	return api.GetNodeById(ctx, client, &api.GetNodeByIdArg{
+		NodeId: health.Id,
 	})
-	if getErr != nil {
-		return mcp.NewToolResultError(getErr.Error()), nil
-	}
-
-	return mcp.NewToolResultJSON(node)
 }
 
 func GetNodeHardwareById() (mcp.Tool, server.ToolHandlerFunc) {
@@ -121,14 +125,14 @@ func handleGetNodeHardwareById(ctx context.Context, req mcp.CallToolRequest) (*m
 
 	client, err := clientForRequest(ctx, req)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return toolError(ctx, req, err), nil
 	}
 
 	hardware, getErr := api.GetNodeHardwareById(ctx, client, &api.GetNodeHardwareByIdArg{
 		NodeId: nodeId,
 	})
 	if getErr != nil {
-		return mcp.NewToolResultError(getErr.Error()), nil
+		return toolError(ctx, req, getErr), nil
 	}
 
 	return mcp.NewToolResultJSON(hardware)
@@ -164,14 +168,14 @@ func handleGetNodeLicenseById(ctx context.Context, req mcp.CallToolRequest) (*mc
 
 	client, err := clientForRequest(ctx, req)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return toolError(ctx, req, err), nil
 	}
 
 	license, getErr := api.GetNodeLicenseById(ctx, client, &api.GetNodeByIdArg{
 		NodeId: nodeId,
 	})
 	if getErr != nil {
-		return mcp.NewToolResultError(getErr.Error()), nil
+		return toolError(ctx, req, getErr), nil
 	}
 
 	// Redact license key if not explicitly requested
@@ -207,14 +211,14 @@ func handleGetNodeStoragePoolsById(ctx context.Context, req mcp.CallToolRequest)
 
 	client, err := clientForRequest(ctx, req)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return toolError(ctx, req, err), nil
 	}
 
 	pools, getErr := api.GetNodeStoragePoolsById(ctx, client, &api.GetNodeStoragePoolsByIdArg{
 		NodeId: nodeId,
 	})
 	if getErr != nil {
-		return mcp.NewToolResultError(getErr.Error()), nil
+		return toolError(ctx, req, getErr), nil
 	}
 
 	return mcp.NewToolResultJSON(&getNodeStoragePoolsByIdResult{
@@ -248,14 +252,14 @@ func handleGetNodePciDevicesById(ctx context.Context, req mcp.CallToolRequest) (
 
 	client, err := clientForRequest(ctx, req)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return toolError(ctx, req, err), nil
 	}
 
 	devices, getErr := api.GetNodePciDevicesById(ctx, client, &api.GetNodePciDevicesByIdArg{
 		NodeId: nodeId,
 	})
 	if getErr != nil {
-		return mcp.NewToolResultError(getErr.Error()), nil
+		return toolError(ctx, req, getErr), nil
 	}
 
 	return mcp.NewToolResultJSON(&getNodePciDevicesByIdResult{
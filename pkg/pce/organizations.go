@@ -40,12 +40,12 @@ func ListOrganizations() (mcp.Tool, server.ToolHandlerFunc) {
 func handleListOrganizations(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	client, err := clientForRequest(ctx, req)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return toolError(ctx, req, err), nil
 	}
 
 	orgs, listErr := api.ListOrganizations(ctx, client, &api.ListOrganizationsArg{})
 	if listErr != nil {
-		return mcp.NewToolResultError(listErr.Error()), nil
+		return toolError(ctx, req, listErr), nil
 	}
 
 	return mcp.NewToolResultJSON(orgs)
@@ -73,14 +73,14 @@ func handleGetOrganizationById(ctx context.Context, req mcp.CallToolRequest) (*m
 
 	var client *api.Client
 	if client, err = clientForRequest(ctx, req); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return toolError(ctx, req, err), nil
 	}
 
 	org, getErr := api.GetOrganizationById(ctx, client, &api.GetOrganizationByIdArg{
 		OrganizationId: orgId,
 	})
 	if getErr != nil {
-		return mcp.NewToolResultError(getErr.Error()), nil
+		return toolError(ctx, req, getErr), nil
 	}
 
 	return mcp.NewToolResultJSON(org)
@@ -100,25 +100,12 @@ func handleGetCurrentOrganization(ctx context.Context, req mcp.CallToolRequest)
 	client, err := clientForRequest(ctx, req)
 	if err != nil {
 		fmt.Println("Error retrieving session:", err)
-		return mcp.NewToolResultError(err.Error()), nil
+		return toolError(ctx, req, err), nil
 	}
 
-	// Get node ID through healthcheck endpoint
-	health, healthErr := api.RunHealthcheck(ctx, client, &api.RunHealthcheckArg{})
-	if healthErr != nil {
-		return mcp.NewToolResultError(healthErr.Error()), nil
-	}
-	// This should never happen
-	if !health.Healthy {
-		return mcp.NewToolResultError("Node is not healthy"), nil
-	}
-
-	nodeId := health.Id
-	node, getErr := api.GetNodeById(ctx, client, &api.GetNodeByIdArg{
-		NodeId: nodeId,
-	})
+	node, getErr := currentNode(ctx, client)
 	if getErr != nil {
-		return mcp.NewToolResultError(getErr.Error()), nil
+		return toolError(ctx, req, getErr), nil
 	}
 
 	// Retrieve organization using organization ID from node
@@ -127,12 +114,12 @@ func handleGetCurrentOrganization(ctx context.Context, req mcp.CallToolRequest)
 		OrganizationId: organizationId,
 	})
 	if orgErr != nil {
-		return mcp.NewToolResultError(orgErr.Error()), nil
+		return toolError(ctx, req, orgErr), nil
 	}
 	return mcp.NewToolResultJSON(org)
 }
 
-/*func ListOrganizationAuditLogsById() (mcp.Tool, server.ToolHandlerFunc) {
+func ListOrganizationAuditLogsById() (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("list_organization_audit_logs_by_id",
 		mcp.WithDescription("Retrieve audit logs for a specific organization. Audit logs provide a record of actions and events that have occurred within the organization, useful for tracking changes and ensuring compliance."),
 		mcp.WithToolAnnotation(mcp.ToolAnnotation{
@@ -143,17 +130,50 @@ func handleGetCurrentOrganization(ctx context.Context, req mcp.CallToolRequest)
 			mcp.Required(),
 			mcp.Description("Unique organization id (format: org-<xxx>)"),
 		),
-		mcp.WithNumber("entries",
-			mcp.Min(0),
-			mcp.Max(5000),
-			mcp.DefaultNumber(50),
-			mcp.Description("The number of audit log entries to retrieve. Default is 50."),
-		),
 		pageNum,
+		pageSize,
+		pageCursor,
 	), handleListOrganizationAuditLogsById
 }
 
-func ListOrganizationUserLockoutsById() (mcp.Tool, server.ToolHandlerFunc) {
+type listOrganizationAuditLogsByIdResult struct {
+	AuditLogs []api.AuditLogEntry `json:"audit_logs"`
+	pageInfo
+}
+
+func handleListOrganizationAuditLogsById(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	organizationId, err := requiredParam[string](req, "organization_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	page, err := pageArgFromRequest(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := clientForRequest(ctx, req)
+	if err != nil {
+		return toolError(ctx, req, err), nil
+	}
+
+	p, listErr := api.FetchPage("/v1/organizations/{organization_id}/audit-logs", page, func(page api.PageArg) (*[]api.AuditLogEntry, *api.APIError) {
+		return api.ListOrganizationAuditLogsById(ctx, client, &api.ListOrganizationAuditLogsByIdArg{
+			OrganizationId: organizationId,
+			PageArg:        page,
+		})
+	})
+	if listErr != nil && !listErr.IsResponseTooLarge() {
+		return toolError(ctx, req, listErr), nil
+	}
+
+	return mcp.NewToolResultJSON(&listOrganizationAuditLogsByIdResult{
+		AuditLogs: p.Items,
+		pageInfo:  newPageInfo(p, listErr),
+	})
+}
+
+/*func ListOrganizationUserLockoutsById() (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("list_organization_user_lockouts_by_id",
 		mcp.WithDescription("Retrieve a list of user lockouts for a specific organization. User lockouts occur when users are temporarily prevented from accessing their accounts due to multiple failed login attempts or security policies."),
 		mcp.WithToolAnnotation(mcp.ToolAnnotation{
@@ -207,7 +227,7 @@ func handleCreateOrganization(ctx context.Context, req mcp.CallToolRequest) (*mc
 
 	client, err := clientForRequest(ctx, req)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return toolError(ctx, req, err), nil
 	}
 
 	org, createErr := api.CreateOrganization(ctx, client, &api.CreateOrganizationArg{
@@ -215,7 +235,7 @@ func handleCreateOrganization(ctx context.Context, req mcp.CallToolRequest) (*mc
 		Description: description,
 	})
 	if createErr != nil {
-		return mcp.NewToolResultError(createErr.Error()), nil
+		return toolError(ctx, req, createErr), nil
 	}
 
 	return mcp.NewToolResultJSON(org)
@@ -254,14 +274,14 @@ func handleDeleteOrganizationById(ctx context.Context, req mcp.CallToolRequest)
 
 	client, err := clientForRequest(ctx, req)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return toolError(ctx, req, err), nil
 	}
 
 	_, deleteErr := api.DeleteOrganizationById(ctx, client, &api.DeleteOrganizationByIdArg{
 		OrganizationId: orgId,
 	})
 	if deleteErr != nil {
-		return mcp.NewToolResultError(deleteErr.Error()), nil
+		return toolError(ctx, req, deleteErr), nil
 	}
 
 	return mcp.NewToolResultText(fmt.Sprintf("Organization %s deleted successfully.", orgId)), nil
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pce
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/PextraCloud/pce-mcp/pkg/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// requestIDMetaKeys are the request metadata fields a client may set to
+// choose the request ID of a tool call.
+var requestIDMetaKeys = []string{"request_id", "requestId"}
+
+const maxRequestIDLength = 128
+
+// RequestID is tool handler middleware that assigns each tool call a request
+// ID, taken from the request metadata or generated. Every PCE API request made
+// by the call carries it as X-Request-ID; it is also logged and included in
+// error envelopes.
+func RequestID(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id := requestIDFromMeta(req)
+		if id == "" {
+			id = api.NewRequestID()
+		}
+		ctx = api.WithRequestID(ctx, id)
+
+		start := time.Now()
+		result, err := next(ctx, req)
+
+		attrs := []slog.Attr{
+			slog.String("tool", req.Params.Name),
+			slog.String("request_id", id),
+			slog.Duration("duration", time.Since(start)),
+		}
+		switch {
+		case err != nil:
+			slog.LogAttrs(ctx, slog.LevelWarn, "tool call failed", append(attrs, slog.String("error", err.Error()))...)
+		case result != nil && result.IsError:
+			slog.LogAttrs(ctx, slog.LevelInfo, "tool call returned an error", attrs...)
+		default:
+			slog.LogAttrs(ctx, slog.LevelDebug, "tool call", attrs...)
+		}
+		return result, err
+	}
+}
+
+// requestIDFromMeta returns the client-chosen request ID, if it is valid.
+func requestIDFromMeta(req mcp.CallToolRequest) string {
+	if req.Params.Meta == nil {
+		return ""
+	}
+	for _, key := range requestIDMetaKeys {
+		if id, ok := req.Params.Meta.AdditionalFields[key].(string); ok && validRequestID(id) {
+			return id
+		}
+	}
+	return ""
+}
+
+// validRequestID accepts IDs safe to put in headers and logs verbatim.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-' || r == '_' || r == '.' || r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,310 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pce
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PextraCloud/pce-mcp/pkg/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const snapshotsHelpText = `\n\nSnapshots save the disk state (and optionally the memory state, for QEMU instances) of an instance at a point in time.
+Take one before risky changes; reverting to it discards every change made since. Snapshots form a tree: each is taken from the state of its parent.`
+
+func ListInstanceSnapshots() (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_instance_snapshots",
+		mcp.WithDescription(fmt.Sprintf("Retrieve the snapshots of a specific instance as a tree (children are snapshots taken from their parent), with their size and whether they include the memory state%s", snapshotsHelpText)),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "List Instance Snapshots",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+		mcp.WithString("node_id",
+			mcp.Required(),
+			mcp.Description("Unique node id (format: node-<xxx>)"),
+		),
+		mcp.WithString("instance_id",
+			mcp.Required(),
+			mcp.Description("Unique instance id (format: inst-<xxx>)"),
+		),
+	), handleListInstanceSnapshots
+}
+
+type listInstanceSnapshotsResult struct {
+	Snapshots   []*api.SnapshotTreeNode `json:"snapshots"`
+	Count       int                     `json:"count"`
+	TotalSizeMB int64                   `json:"total_size_mb"`
+}
+
+func handleListInstanceSnapshots(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	nodeId, err := requiredParam[string](req, "node_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	instanceId, err := requiredParam[string](req, "instance_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := clientForRequest(ctx, req)
+	if err != nil {
+		return toolError(ctx, req, err), nil
+	}
+
+	snapshots, listErr := api.ListInstanceSnapshots(ctx, client, &api.ListInstanceSnapshotsArg{
+		NodeId:     nodeId,
+		InstanceId: instanceId,
+	})
+	if listErr != nil {
+		return toolError(ctx, req, listErr), nil
+	}
+
+	result := &listInstanceSnapshotsResult{
+		Snapshots: api.SnapshotTree(*snapshots),
+		Count:     len(*snapshots),
+	}
+	for _, s := range *snapshots {
+		result.TotalSizeMB += s.SizeMB
+	}
+	return mcp.NewToolResultJSON(result)
+}
+
+func CreateInstanceSnapshot() (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("create_instance_snapshot",
+		mcp.WithDescription(fmt.Sprintf("Take a snapshot of a specific instance. Returns the task_id of the snapshot, to be tracked with wait_for_task.%s", snapshotsHelpText)),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title: "Create Instance Snapshot",
+		}),
+		mcp.WithString("node_id",
+			mcp.Required(),
+			mcp.Description("Unique node id (format: node-<xxx>)"),
+		),
+		mcp.WithString("instance_id",
+			mcp.Required(),
+			mcp.Description("Unique instance id (format: inst-<xxx>)"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.MinLength(nameDefaultMinLength),
+			mcp.MaxLength(nameDefaultMaxLength),
+			mcp.Pattern(nameRegex(nameDefaultMinLength, nameDefaultMaxLength)),
+			mcp.Description("The name of the new snapshot, e.g. before-upgrade."),
+		),
+		mcp.WithString("description",
+			mcp.MaxLength(descriptionDefaultMaxLength),
+			mcp.Description("A brief description of the snapshot, e.g. why it was taken."),
+		),
+		mcp.WithBoolean("include_memory",
+			mcp.DefaultBool(false),
+			mcp.Description("Also save the memory state, so that reverting resumes the instance where it was. Only for running QEMU instances; takes longer and needs as much space as the instance memory. Default is false."),
+		),
+	), handleCreateInstanceSnapshot
+}
+
+func handleCreateInstanceSnapshot(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	nodeId, err := requiredParam[string](req, "node_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	instanceId, err := requiredParam[string](req, "instance_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	name, err := requiredParam[string](req, "name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	description, err := optionalParam[string](req, "description")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	includeMemory, err := optionalParam[bool](req, "include_memory")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := clientForRequest(ctx, req)
+	if err != nil {
+		return toolError(ctx, req, err), nil
+	}
+
+	arg := &api.CreateInstanceSnapshotArg{
+		NodeId:        nodeId,
+		InstanceId:    instanceId,
+		Name:          name,
+		Description:   description,
+		IncludeMemory: includeMemory,
+	}
+	if checkErr := api.CheckCreateInstanceSnapshot(ctx, client, arg); checkErr != nil {
+		return toolError(ctx, req, checkErr), nil
+	}
+	res, createErr := api.CreateInstanceSnapshot(ctx, client, arg)
+	if createErr != nil {
+		return toolError(ctx, req, createErr), nil
+	}
+
+	return mcp.NewToolResultJSON(struct {
+		Message    string `json:"message"`
+		SnapshotId string `json:"snapshot_id"`
+		TaskId     string `json:"task_id"`
+	}{
+		Message:    "Snapshot started. Use wait_for_task with the task_id to confirm the result.",
+		SnapshotId: res.SnapshotId,
+		TaskId:     res.TaskId,
+	})
+}
+
+func RevertInstanceSnapshot() (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("revert_instance_snapshot",
+		mcp.WithDescription(fmt.Sprintf("Revert a specific instance to one of its snapshots. Every change made to the instance since the snapshot is lost, unless another snapshot was taken first. Returns the task_id of the revert, to be tracked with wait_for_task.%s", snapshotsHelpText)),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Revert Instance Snapshot",
+			DestructiveHint: mcp.ToBoolPtr(true),
+		}),
+		mcp.WithString("node_id",
+			mcp.Required(),
+			mcp.Description("Unique node id (format: node-<xxx>)"),
+		),
+		mcp.WithString("instance_id",
+			mcp.Required(),
+			mcp.Description("Unique instance id (format: inst-<xxx>)"),
+		),
+		mcp.WithString("snapshot_id",
+			mcp.Required(),
+			mcp.Description("Unique snapshot id, as returned by list_instance_snapshots"),
+		),
+		mcp.WithBoolean("are_you_sure",
+			mcp.Required(),
+			mcp.Description("A safety check to prevent accidental data loss. Must be set to true to proceed with the revert."),
+		),
+	), handleRevertInstanceSnapshot
+}
+
+func handleRevertInstanceSnapshot(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	nodeId, err := requiredParam[string](req, "node_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	instanceId, err := requiredParam[string](req, "instance_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	snapshotId, err := requiredParam[string](req, "snapshot_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	areYouSure, err := requiredParam[bool](req, "are_you_sure")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if !areYouSure {
+		return mcp.NewToolResultError("Revert not confirmed. Set 'are_you_sure' to true to proceed."), nil
+	}
+
+	client, err := clientForRequest(ctx, req)
+	if err != nil {
+		return toolError(ctx, req, err), nil
+	}
+
+	res, revertErr := api.RevertInstanceSnapshot(ctx, client, &api.RevertInstanceSnapshotArg{
+		NodeId:     nodeId,
+		InstanceId: instanceId,
+		SnapshotId: snapshotId,
+	})
+	if revertErr != nil {
+		return toolError(ctx, req, revertErr), nil
+	}
+
+	return mcp.NewToolResultJSON(struct {
+		Message string `json:"message"`
+		TaskId  string `json:"task_id"`
+	}{
+		Message: "Revert started. Use wait_for_task with the task_id to confirm the result.",
+		TaskId:  res.TaskId,
+	})
+}
+
+func DeleteInstanceSnapshot() (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_instance_snapshot",
+		mcp.WithDescription(fmt.Sprintf("Delete a snapshot of a specific instance; the instance itself is unaffected, and snapshots taken from it are kept. Returns the task_id of the deletion, to be tracked with wait_for_task.%s", snapshotsHelpText)),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Delete Instance Snapshot",
+			DestructiveHint: mcp.ToBoolPtr(true),
+		}),
+		mcp.WithString("node_id",
+			mcp.Required(),
+			mcp.Description("Unique node id (format: node-<xxx>)"),
+		),
+		mcp.WithString("instance_id",
+			mcp.Required(),
+			mcp.Description("Unique instance id (format: inst-<xxx>)"),
+		),
+		mcp.WithString("snapshot_id",
+			mcp.Required(),
+			mcp.Description("Unique snapshot id, as returned by list_instance_snapshots"),
+		),
+		mcp.WithBoolean("are_you_sure",
+			mcp.Required(),
+			mcp.Description("A safety check to prevent accidental deletions. Must be set to true to proceed with deletion."),
+		),
+	), handleDeleteInstanceSnapshot
+}
+
+func handleDeleteInstanceSnapshot(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	nodeId, err := requiredParam[string](req, "node_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	instanceId, err := requiredParam[string](req, "instance_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	snapshotId, err := requiredParam[string](req, "snapshot_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	areYouSure, err := requiredParam[bool](req, "are_you_sure")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if !areYouSure {
+		return mcp.NewToolResultError("Deletion not confirmed. Set 'are_you_sure' to true to proceed."), nil
+	}
+
+	client, err := clientForRequest(ctx, req)
+	if err != nil {
+		return toolError(ctx, req, err), nil
+	}
+
+	res, deleteErr := api.DeleteInstanceSnapshot(ctx, client, &api.DeleteInstanceSnapshotArg{
+		NodeId:     nodeId,
+		InstanceId: instanceId,
+		SnapshotId: snapshotId,
+	})
+	if deleteErr != nil {
+		return toolError(ctx, req, deleteErr), nil
+	}
+
+	return mcp.NewToolResultJSON(struct {
+		Message string `json:"message"`
+		TaskId  string `json:"task_id"`
+	}{
+		Message: "Snapshot deletion started. Use wait_for_task with the task_id to confirm the result.",
+		TaskId:  res.TaskId,
+	})
+}
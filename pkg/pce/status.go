@@ -0,0 +1,79 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pce
+
+import (
+	"context"
+
+	"github.com/PextraCloud/pce-mcp/pkg/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func ServerStatus() (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("server_status",
+		mcp.WithDescription("Report whether the Pextra CloudEnvironment (PCE) API is reachable from this MCP server, including the circuit breaker state and cache statistics. Use this tool when other tools fail with 'unavailable' errors, and tell the user that PCE is unreachable instead of retrying each tool."),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Server Status",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	), handleServerStatus
+}
+
+type serverStatusResult struct {
+	PCEReachable     bool                        `json:"pce_reachable"`
+	Message          string                      `json:"message"`
+	CircuitBreaker   api.BreakerStatus           `json:"circuit_breaker"`
+	Healthcheck      *api.RunHealthcheckResponse `json:"healthcheck,omitempty"`
+	HealthcheckError string                      `json:"healthcheck_error,omitempty"`
+	Cache            *api.CacheStats             `json:"cache,omitempty"`
+}
+
+func handleServerStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	client, err := clientForRequest(ctx, req)
+	if err != nil {
+		return toolError(ctx, req, err), nil
+	}
+
+	result := &serverStatusResult{
+		CircuitBreaker: client.Breaker.Status(),
+	}
+	if client.Cache != nil {
+		stats := client.Cache.Stats()
+		result.Cache = &stats
+	}
+
+	// Don't bother PCE while the breaker is open; it is probed in the background
+	if result.CircuitBreaker.State == api.BreakerStateOpen {
+		result.Message = "PCE is unreachable; requests fail fast until a background health probe succeeds. Tell the user instead of retrying tools."
+		return mcp.NewToolResultJSON(result)
+	}
+
+	health, healthErr := api.RunHealthcheck(ctx, client, &api.RunHealthcheckArg{})
+	switch {
+	case healthErr != nil:
+		result.HealthcheckError = healthErr.Error()
+		result.Message = "PCE healthcheck failed."
+	case !health.Healthy:
+		result.Healthcheck = health
+		result.Message = "PCE is reachable but reports being unhealthy."
+	default:
+		result.PCEReachable = true
+		result.Healthcheck = health
+		result.Message = "PCE is reachable and healthy."
+	}
+	return mcp.NewToolResultJSON(result)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pce
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PextraCloud/pce-mcp/pkg/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const tasksHelpText = `\n\nTasks track long-running operations (e.g., power actions) started by other tools, which return a task_id.
+A task is pending or running until it reaches a terminal state: succeeded, failed or cancelled.`
+
+const (
+	waitForTaskDefaultTimeoutSeconds = 60
+	listTasksDefaultLimit            = 20
+)
+
+func GetTaskStatus() (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_task_status",
+		mcp.WithDescription(fmt.Sprintf("Retrieve the current state, progress, error message and timestamps of a specific task%s", tasksHelpText)),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Get Task Status",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("Unique task id, as returned by the tool that started the operation"),
+		),
+	), handleGetTaskStatus
+}
+
+func handleGetTaskStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := requiredParam[string](req, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := clientForRequest(ctx, req)
+	if err != nil {
+		return toolError(ctx, req, err), nil
+	}
+
+	task, getErr := api.GetTask(ctx, client, &api.GetTaskArg{
+		TaskId: taskId,
+	})
+	if getErr != nil {
+		return toolError(ctx, req, getErr), nil
+	}
+
+	return mcp.NewToolResultJSON(task)
+}
+
+func ListRecentTasks() (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_recent_tasks",
+		mcp.WithDescription(fmt.Sprintf("Retrieve the most recent tasks, optionally limited to a specific node%s", tasksHelpText)),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "List Recent Tasks",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+		mcp.WithString("node_id",
+			mcp.Description("Unique node id (format: node-<xxx>). If omitted, tasks from all nodes are returned."),
+		),
+		mcp.WithNumber("limit",
+			mcp.Min(1),
+			mcp.Max(500),
+			mcp.DefaultNumber(listTasksDefaultLimit),
+			mcp.Description(fmt.Sprintf("The maximum number of tasks to retrieve. Default is %d.", listTasksDefaultLimit)),
+		),
+	), handleListRecentTasks
+}
+
+type listRecentTasksResult struct {
+	Tasks *api.ListTasksResponse `json:"tasks"`
+}
+
+func handleListRecentTasks(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	nodeId, err := optionalParam[string](req, "node_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	limit, err := optionalParam[float64](req, "limit")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if limit <= 0 {
+		limit = listTasksDefaultLimit
+	}
+
+	client, err := clientForRequest(ctx, req)
+	if err != nil {
+		return toolError(ctx, req, err), nil
+	}
+
+	tasks, listErr := api.ListTasks(ctx, client, &api.ListTasksArg{
+		NodeId: nodeId,
+		Limit:  int(limit),
+	})
+	if listErr != nil {
+		return toolError(ctx, req, listErr), nil
+	}
+
+	return mcp.NewToolResultJSON(&listRecentTasksResult{
+		Tasks: tasks,
+	})
+}
+
+func WaitForTask() (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("wait_for_task",
+		mcp.WithDescription(fmt.Sprintf("Wait until a specific task reaches a terminal state and return its final status. Use this tool after starting an operation to confirm whether it actually worked.%s", tasksHelpText)),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Wait For Task",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("Unique task id, as returned by the tool that started the operation"),
+		),
+		mcp.WithNumber("poll_interval_seconds",
+			mcp.Min(1),
+			mcp.Max(60),
+			mcp.DefaultNumber(2),
+			mcp.Description("How often to check the task state, in seconds. Default is 2."),
+		),
+		withTimeoutSeconds(waitForTaskDefaultTimeoutSeconds),
+	), handleWaitForTask
+}
+
+type waitForTaskResult struct {
+	Completed bool            `json:"completed"`
+	Task      *api.TaskDetail `json:"task"`
+}
+
+func handleWaitForTask(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskId, err := requiredParam[string](req, "task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	pollInterval, err := optionalParam[float64](req, "poll_interval_seconds")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if pollInterval <= 0 {
+		pollInterval = 2
+	}
+	client, err := clientForRequest(ctx, req)
+	if err != nil {
+		return toolError(ctx, req, err), nil
+	}
+
+	// The wait is bounded by timeout_seconds or the configured tool timeout
+	// (see Timeouts), else by the default
+	waitCtx := ctx
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, waitForTaskDefaultTimeoutSeconds*time.Second)
+		defer cancel()
+	}
+
+	task, waitErr := api.WaitForTask(waitCtx, client, taskId, time.Duration(pollInterval*float64(time.Second)))
+	if waitErr != nil {
+		// Report the last known state if the wait timed out (rather than being cancelled)
+		if task != nil && errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+			return mcp.NewToolResultJSON(&waitForTaskResult{
+				Completed: false,
+				Task:      task,
+			})
+		}
+		return toolError(ctx, req, waitErr), nil
+	}
+
+	return mcp.NewToolResultJSON(&waitForTaskResult{
+		Completed: true,
+		Task:      task,
+	})
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 Pextra Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pce
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/PextraCloud/pce-mcp/pkg/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// timeoutSecondsParam lets callers of long-running tools choose the timeout
+// of the call.
+const (
+	timeoutSecondsParam = "timeout_seconds"
+	maxTimeoutSeconds   = 600
+)
+
+// withTimeoutSeconds declares the timeout_seconds argument of a long-running
+// tool, applied by the Timeouts middleware.
+func withTimeoutSeconds(defaultSeconds int) mcp.ToolOption {
+	return mcp.WithNumber(timeoutSecondsParam,
+		mcp.Min(1),
+		mcp.Max(maxTimeoutSeconds),
+		mcp.DefaultNumber(float64(defaultSeconds)),
+		mcp.Description(fmt.Sprintf("The maximum time to wait, in seconds. Default is %d.", defaultSeconds)),
+	)
+}
+
+// Timeouts returns tool handler middleware bounding each tool call by its
+// timeout_seconds argument, for tools declaring one, or else by the timeout
+// configured for the tool in timeouts (by tool name). The PCE API requests of
+// a bounded call are limited by the call deadline only, instead of the
+// client's per-request timeouts, so that long operations aren't cut short.
+func Timeouts(timeouts map[string]time.Duration) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			timeout := timeouts[req.Params.Name]
+			if declaresParam(ctx, req.Params.Name, timeoutSecondsParam) {
+				seconds, err := optionalParam[float64](req, timeoutSecondsParam)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if seconds > 0 {
+					timeout = time.Duration(min(seconds, maxTimeoutSeconds) * float64(time.Second))
+				}
+			}
+			if timeout <= 0 {
+				return next(ctx, req)
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			return next(api.WithRequestTimeout(ctx, 0), req)
+		}
+	}
+}
+
+// declaresParam reports whether the tool registered as name has the argument param.
+func declaresParam(ctx context.Context, name, param string) bool {
+	s := server.ServerFromContext(ctx)
+	if s == nil {
+		return false
+	}
+	tool := s.GetTool(name)
+	if tool == nil {
+		return false
+	}
+	_, ok := tool.Tool.InputSchema.Properties[param]
+	return ok
+}
@@ -34,11 +34,15 @@ func ListUsersInOrganizationById() (mcp.Tool, server.ToolHandlerFunc) {
 			mcp.Required(),
 			mcp.Description("Unique organization id (format: org-<xxx>)"),
 		),
+		pageNum,
+		pageSize,
+		pageCursor,
 	), handleListUsersInOrganizationById
 }
 
 type listUsersInOrganizationByIdResult struct {
-	Users *api.ListUsersInOrganizationByIdResponse `json:"users"`
+	Users []api.UserList `json:"users"`
+	pageInfo
 }
 
 func handleListUsersInOrganizationById(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -46,21 +50,29 @@ func handleListUsersInOrganizationById(ctx context.Context, req mcp.CallToolRequ
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	page, err := pageArgFromRequest(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	client, err := clientForRequest(ctx, req)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return toolError(ctx, req, err), nil
 	}
 
-	users, getErr := api.ListUsersInOrganizationById(ctx, client, &api.ListUsersInOrganizationByIdArg{
-		OrganizationId: organizationId,
+	p, getErr := api.FetchPage("/v1/users", page, func(page api.PageArg) (*[]api.UserList, *api.APIError) {
+		return api.ListUsersInOrganizationById(ctx, client, &api.ListUsersInOrganizationByIdArg{
+			OrganizationId: organizationId,
+			PageArg:        page,
+		})
 	})
-	if getErr != nil {
-		return mcp.NewToolResultError(getErr.Error()), nil
+	if getErr != nil && !getErr.IsResponseTooLarge() {
+		return toolError(ctx, req, getErr), nil
 	}
 
 	return mcp.NewToolResultJSON(&listUsersInOrganizationByIdResult{
-		Users: users,
+		Users:    p.Items,
+		pageInfo: newPageInfo(p, getErr),
 	})
 }
 
@@ -86,14 +98,14 @@ func handleDeleteUserById(ctx context.Context, req mcp.CallToolRequest) (*mcp.Ca
 
 	client, err := clientForRequest(ctx, req)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return toolError(ctx, req, err), nil
 	}
 
 	_, deleteErr := api.DeleteUserById(ctx, client, &api.DeleteUserByIdArg{
 		UserId: userId,
 	})
 	if deleteErr != nil {
-		return mcp.NewToolResultError(deleteErr.Error()), nil
+		return toolError(ctx, req, deleteErr), nil
 	}
 
 	return mcp.NewToolResultText("User deleted successfully"), nil
@@ -121,7 +133,7 @@ func handleInvalidateUserSessionsById(ctx context.Context, req mcp.CallToolReque
 
 	client, err := clientForRequest(ctx, req)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return toolError(ctx, req, err), nil
 	}
 
 	_, invalidateErr := api.InvalidateUserSessionsById(ctx, client, &api.InvalidateUserSessionsByIdArg{
@@ -129,7 +141,7 @@ func handleInvalidateUserSessionsById(ctx context.Context, req mcp.CallToolReque
 		InvalidateCurrent: false,
 	})
 	if invalidateErr != nil {
-		return mcp.NewToolResultError(invalidateErr.Error()), nil
+		return toolError(ctx, req, invalidateErr), nil
 	}
 
 	return mcp.NewToolResultText("User sessions invalidated successfully"), nil
@@ -17,6 +17,7 @@ package pce
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 
 	"github.com/PextraCloud/pce-mcp/internal/session"
@@ -42,6 +43,83 @@ var pageNum = mcp.WithNumber("page",
 	mcp.Description("The page number for paginated results. Default is 1."),
 )
 
+var pageSize = mcp.WithNumber("page_size",
+	mcp.Min(1),
+	mcp.Max(api.MaxPageSize),
+	mcp.DefaultNumber(api.DefaultPageSize),
+	mcp.Description(fmt.Sprintf("The number of results per page. Default is %d.", api.DefaultPageSize)),
+)
+
+var pageCursor = mcp.WithString("cursor",
+	mcp.Description("Opaque cursor from the next_cursor field of a previous response, to fetch the next page. Overrides page and page_size."),
+)
+
+// pageInfo is embedded in the results of paginated tools.
+type pageInfo struct {
+	Page       int    `json:"page"`
+	PageSize   int    `json:"page_size"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	// The PCE response exceeded the size limit; the page is incomplete
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// newPageInfo describes p. apiErr is the error returned along with the
+// items, i.e. nil or a truncation error.
+func newPageInfo[T any](p api.Page[T], apiErr *api.APIError) pageInfo {
+	info := pageInfo{
+		Page:      p.Page,
+		PageSize:  p.PageSize,
+		HasMore:   p.HasMore,
+		Truncated: apiErr.IsResponseTooLarge(),
+	}
+	if p.HasMore {
+		info.NextCursor = encodeCursor(api.PageArg{Page: p.Page + 1, PageSize: p.PageSize})
+	}
+	return info
+}
+
+// pageArgFromRequest reads the page, page_size and cursor parameters.
+func pageArgFromRequest(r mcp.CallToolRequest) (api.PageArg, error) {
+	cursor, err := optionalParam[string](r, "cursor")
+	if err != nil {
+		return api.PageArg{}, err
+	}
+	if cursor != "" {
+		return decodeCursor(cursor)
+	}
+
+	page, err := optionalParam[float64](r, "page")
+	if err != nil {
+		return api.PageArg{}, err
+	}
+	size, err := optionalParam[float64](r, "page_size")
+	if err != nil {
+		return api.PageArg{}, err
+	}
+	if page < 0 || size < 0 || size > api.MaxPageSize {
+		return api.PageArg{}, fmt.Errorf("page must be >= 1 and page_size between 1 and %d", api.MaxPageSize)
+	}
+	// Always paginate; unset values fall back to the defaults
+	return api.PageArg{Page: max(1, int(page)), PageSize: int(size)}, nil
+}
+
+func encodeCursor(p api.PageArg) string {
+	return base64.RawURLEncoding.EncodeToString(fmt.Appendf(nil, "%d:%d", p.Page, p.PageSize))
+}
+
+func decodeCursor(cursor string) (api.PageArg, error) {
+	var p api.PageArg
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		_, err = fmt.Sscanf(string(b), "%d:%d", &p.Page, &p.PageSize)
+	}
+	if err != nil || p.Page < 1 || p.PageSize < 1 || p.PageSize > api.MaxPageSize {
+		return api.PageArg{}, fmt.Errorf("invalid cursor")
+	}
+	return p, nil
+}
+
 // From: https://github.com/github/github-mcp-server/blob/0188cc0041d86daec4080ef2e48de238919c7909/pkg/github/server.go#L68
 // requiredParam is a helper function that can be used to fetch a requested parameter from the request.
 // It does the following checks:
@@ -99,5 +177,5 @@ func clientForRequest(ctx context.Context, req mcp.CallToolRequest) (*api.Client
 	if req.Header != nil {
 		authorization = req.Header.Get("Authorization")
 	}
-	return session.GetSession(s.SessionID(), authorization)
+	return session.GetSession(ctx, s.SessionID(), authorization)
 }